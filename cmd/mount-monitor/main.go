@@ -11,6 +11,7 @@ import (
 
 	"github.com/cscheib/debrid-mount-monitor/internal/config"
 	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/cscheib/debrid-mount-monitor/internal/metrics"
 	"github.com/cscheib/debrid-mount-monitor/internal/monitor"
 	"github.com/cscheib/debrid-mount-monitor/internal/server"
 	"github.com/cscheib/debrid-mount-monitor/internal/watchdog"
@@ -20,6 +21,11 @@ import (
 var Version = "dev"
 
 func main() {
+	// Probe helper for mounts with isolateProbes: run one check and exit
+	if len(os.Args) == 2 && os.Args[1] == health.ProbeSubcommand {
+		os.Exit(health.RunProbeHelper(os.Stdin, os.Stdout))
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -53,26 +59,78 @@ func main() {
 		"read_timeout", cfg.ReadTimeout.String(),
 		"shutdown_timeout", cfg.ShutdownTimeout.String(),
 		"failure_threshold", cfg.FailureThreshold,
+		"recovery_threshold", cfg.RecoveryThreshold,
+		"max_concurrent_checks", cfg.MaxConcurrentChecks,
 		"http_port", cfg.HTTPPort,
+		"api_tokens_file", cfg.APITokensFile,
 		"log_level", cfg.LogLevel,
 		"log_format", cfg.LogFormat,
 		"canary_file", cfg.CanaryFile,
 		"watchdog_enabled", cfg.Watchdog.Enabled,
 		"watchdog_restart_delay", cfg.Watchdog.RestartDelay.String(),
+		"watchdog_remediation", cfg.Watchdog.Remediation,
 	)
 
 	// Create mounts from configuration
 	mounts := make([]*health.Mount, len(cfg.Mounts))
 	for i, mc := range cfg.Mounts {
-		mounts[i] = health.NewMountWithCheckType(mc.Name, mc.Path, mc.CanaryFile, mc.CheckType, mc.FailureThreshold)
+		mount, err := newMount(mc)
+		if err != nil {
+			logger.Error("invalid mount configuration", "mount", mc.Name, "error", err)
+			os.Exit(1)
+		}
+		mounts[i] = mount
 		attrs := []any{
 			"name", mc.Name,
 			"path", mc.Path,
-			"check_type", mounts[i].CheckType,
 			"failureThreshold", mc.FailureThreshold,
+			"recoveryThreshold", mc.RecoveryThreshold,
+		}
+		if len(mc.FailureRules) > 0 {
+			attrs = append(attrs, "failure_rules", len(mc.FailureRules))
+		}
+		if mounts[i].FailureWindow.Enabled() {
+			attrs = append(attrs, "failure_window", fmt.Sprintf("%d/%d", mc.FailureWindow.Failures, mc.FailureWindow.Size))
+		}
+		if mounts[i].FlapPolicy.Enabled() {
+			attrs = append(attrs, "flap_threshold", mc.FlapDetection.Threshold, "flap_window", mc.FlapDetection.Window.String())
+		}
+		if mc.Latency.Warn > 0 {
+			attrs = append(attrs, "latency_warn", mc.Latency.Warn.String())
+		}
+		if mc.Latency.Fail > 0 {
+			attrs = append(attrs, "latency_fail", mc.Latency.Fail.String())
+		}
+		if mc.Latency.FailAfter > 0 {
+			attrs = append(attrs, "latency_fail_after", mc.Latency.FailAfter)
+		}
+		if mc.IsolateProbes {
+			attrs = append(attrs, "isolate_probes", true)
+		}
+		if mc.AdaptiveInterval.Degraded > 0 {
+			attrs = append(attrs, "degraded_interval", mc.AdaptiveInterval.Degraded.String())
 		}
-		if mounts[i].CheckType == health.CheckTypeCanary {
-			attrs = append(attrs, "canary", mounts[i].CanaryPath)
+		if mc.AdaptiveInterval.Stable > 0 {
+			attrs = append(attrs, "stable_interval", mc.AdaptiveInterval.Stable.String(), "stable_after", mc.AdaptiveInterval.StableAfter)
+		}
+		if mounts[i].IsComposite() {
+			checkTypes := make([]string, len(mounts[i].Checks))
+			for j, spec := range mounts[i].Checks {
+				checkTypes[j] = spec.CheckType
+			}
+			checkMode := mc.CheckMode
+			if checkMode == "" {
+				checkMode = health.CheckModeAll
+			}
+			attrs = append(attrs, "checks", checkTypes, "check_mode", checkMode)
+		} else {
+			attrs = append(attrs, "check_type", mounts[i].CheckType)
+			if mounts[i].CanaryPath != "" {
+				attrs = append(attrs, "canary", mounts[i].CanaryPath)
+			}
+			if mc.RequireMountpoint {
+				attrs = append(attrs, "require_mountpoint", true)
+			}
 		}
 		logger.Info("mount registered", attrs...)
 	}
@@ -82,6 +140,7 @@ func main() {
 
 	// Create monitor
 	mon := monitor.New(mounts, checker, cfg.CheckInterval, cfg.FailureThreshold, logger)
+	mon.SetMaxConcurrent(cfg.MaxConcurrentChecks)
 
 	// Initialize watchdog
 	// Read pod identity from Downward API environment variables
@@ -96,20 +155,45 @@ func main() {
 			"hint", "set POD_NAME and POD_NAMESPACE env vars via Downward API")
 	}
 
+	remediations, err := newRemediations(cfg)
+	if err != nil {
+		logger.Error("invalid watchdog remediation", "error", err)
+		os.Exit(1)
+	}
+
 	watchdogCfg := watchdog.Config{
 		Enabled:             cfg.Watchdog.Enabled,
 		RestartDelay:        cfg.Watchdog.RestartDelay,
 		MaxRetries:          cfg.Watchdog.MaxRetries,
 		RetryBackoffInitial: cfg.Watchdog.RetryBackoffInitial,
 		RetryBackoffMax:     cfg.Watchdog.RetryBackoffMax,
+		Remediations:        remediations,
+		RemediationChecks:   cfg.Watchdog.RemediationChecks,
 	}
 	wd := watchdog.NewWatchdog(watchdogCfg, podName, podNamespace, logger)
 
 	// Connect watchdog to monitor for state change notifications
 	mon.SetWatchdog(wd)
 
+	// Create metrics collector fed by the monitor and exported by the HTTP server
+	collector := metrics.New(mounts)
+	collector.SetWatchdog(wd)
+	mon.SetMetrics(collector)
+
 	// Create HTTP server
 	srv := server.New(mounts, cfg.HTTPPort, Version, logger)
+	srv.SetMetrics(collector)
+	srv.SetChecker(mon, cfg.ReadTimeout)
+	if cfg.APITokensFile != "" {
+		tokens, err := server.LoadTokenStore(cfg.APITokensFile, logger)
+		if err != nil {
+			logger.Error("failed to load api tokens", "error", err)
+			os.Exit(1)
+		}
+		srv.SetAuth(tokens)
+	} else {
+		logger.Info("api authentication disabled, admin endpoints unavailable", "reason", "no apiTokensFile configured")
+	}
 
 	// Setup shutdown context
 	ctx, cancel := context.WithCancel(context.Background())
@@ -164,6 +248,71 @@ func main() {
 	os.Exit(0)
 }
 
+// newMount creates a health.Mount from its configuration, including any
+// check-type specific settings and composite checks.
+func newMount(mc config.MountConfig) (*health.Mount, error) {
+	mount := health.NewMountWithCheckType(mc.Name, mc.Path, mc.CanaryFile, mc.CheckType, mc.FailureThreshold)
+	spec, err := mc.SingleCheck().CheckSpec(mc.Path)
+	if err != nil {
+		return nil, err
+	}
+	mount.CheckSpec = spec
+	for j, cc := range mc.Checks {
+		spec, err := cc.CheckSpec(mc.Path)
+		if err != nil {
+			return nil, fmt.Errorf("checks[%d]: %w", j, err)
+		}
+		mount.Checks = append(mount.Checks, spec)
+	}
+	mount.CheckMode = mc.CheckMode
+	mount.FailurePolicies = mc.FailurePolicies()
+	mount.RecoveryThreshold = mc.RecoveryThreshold
+	mount.FailureWindow = mc.FailureWindow.FailureWindow()
+	mount.FlapPolicy = mc.FlapDetection.FlapPolicy()
+	mount.Latency = mc.Latency.LatencyPolicy()
+	mount.IsolateProbes = mc.IsolateProbes
+	mount.Intervals = mc.AdaptiveInterval.AdaptiveInterval()
+	return mount, nil
+}
+
+// newRemediations builds the watchdog remediation ladder from configuration.
+// rclone steps act on the mounts that have an rclone rc endpoint configured;
+// lazy-unmount acts on any mount that fails with a stale-mount error.
+func newRemediations(cfg *config.Config) ([]watchdog.Remediation, error) {
+	if len(cfg.Watchdog.Remediation) == 0 {
+		return nil, nil
+	}
+
+	targets := make(map[string]watchdog.RcloneTarget)
+	for _, mc := range cfg.Mounts {
+		rc, ok := mc.RcloneEndpoint()
+		if !ok {
+			continue
+		}
+		targets[mc.Path] = watchdog.RcloneTarget{
+			URL:        rc.URL,
+			Username:   rc.Username,
+			Password:   rc.Password,
+			MountPoint: rc.MountPoint,
+			Remote:     mc.RCRemote,
+		}
+	}
+
+	var remediations []watchdog.Remediation
+	for _, name := range cfg.Watchdog.Remediation {
+		if name == config.RemediationLazyUnmount {
+			remediations = append(remediations, watchdog.NewLazyUnmountRemediation(nil))
+			continue
+		}
+		r, err := watchdog.NewRcloneRemediation(name, targets)
+		if err != nil {
+			return nil, err
+		}
+		remediations = append(remediations, r)
+	}
+	return remediations, nil
+}
+
 // setupLogger creates a structured logger based on configuration.
 // Per FR-012: debug/info → stdout, warn/error → stderr
 func setupLogger(level, format string) *slog.Logger {
@@ -249,7 +398,12 @@ func runInitMode(cfg *config.Config, logger *slog.Logger) int {
 	// Create mounts from configuration
 	mounts := make([]*health.Mount, len(cfg.Mounts))
 	for i, mc := range cfg.Mounts {
-		mounts[i] = health.NewMountWithCheckType(mc.Name, mc.Path, mc.CanaryFile, mc.CheckType, mc.FailureThreshold)
+		mount, err := newMount(mc)
+		if err != nil {
+			logger.Error("invalid mount configuration", "mount", mc.Name, "error", err)
+			return 1
+		}
+		mounts[i] = mount
 	}
 
 	// Create health checker
@@ -257,3 +257,19 @@ func TestRunInitMode_EmptyMounts(t *testing.T) {
 
 	is.Equal(exitCode, 0) // no mounts = all healthy (vacuously true)
 }
+
+// TestNewRemediations_Names tests that every remediation step name accepted by
+// the configuration builds the watchdog step of that name.
+func TestNewRemediations_Names(t *testing.T) {
+	is := is.New(t)
+
+	cfg := config.DefaultConfig()
+	cfg.Watchdog.Remediation = []string{config.RemediationVFSRefresh, config.RemediationRemount, config.RemediationLazyUnmount}
+
+	steps, err := newRemediations(cfg)
+	is.NoErr(err)           // every configured step should be known to the watchdog
+	is.Equal(len(steps), 3) // one step per name
+	for i, step := range steps {
+		is.Equal(step.Name(), cfg.Watchdog.Remediation[i]) // watchdog step has the configured name
+	}
+}
@@ -2,20 +2,279 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
 	"github.com/hashicorp/go-multierror"
 	flag "github.com/spf13/pflag"
 )
 
 // MountConfig holds per-mount configuration settings.
 type MountConfig struct {
-	Name             string // Human-readable identifier (optional)
-	Path             string // Filesystem path to mount point (required) - can be absolute or relative
-	CanaryFile       string // Relative path to canary file within mount (optional, inherits global)
-	CheckType        string // Health check type: "canary" or "directory" (optional, defaults to canary)
-	FailureThreshold int    // Consecutive failures before unhealthy (0 = use global failureThreshold)
+	Name              string                 // Human-readable identifier (optional)
+	Path              string                 // Filesystem path to mount point (required) - can be absolute or relative
+	CanaryFile        string                 // Relative path to canary file within mount (optional, inherits global)
+	CheckType         string                 // Health check type registered with health.RegisterCheckType, see health.CheckTypes (optional, defaults to canary)
+	FailureThreshold  int                    // Consecutive failures before unhealthy (0 = use global failureThreshold)
+	RecoveryThreshold int                    // Consecutive passing checks before a failing mount is healthy again (0 = use global recoveryThreshold)
+	FailureWindow     FailureWindowConfig    // Sliding-window failure counting (inherits the global settings)
+	FlapDetection     FlapDetectionConfig    // Flap detection settings (inherits the global settings)
+	Latency           LatencyConfig          // Latency thresholds for slow checks (optional, disabled by default)
+	IsolateProbes     bool                   // Run checks in a subprocess that is killed on timeout (inherits the global setting)
+	AdaptiveInterval  AdaptiveIntervalConfig // State-dependent check intervals (inherits the global settings)
+
+	// Per error class failure handling, keyed by failure reason name, e.g. "not_connected" (optional)
+	FailureRules map[string]FailureRule
+
+	// Composite check settings (mutually exclusive with CheckType)
+	Checks    []CheckConfig // Checks to run in order; replaces the single CheckType (optional)
+	CheckMode string        // How check results combine: "all" (default) or "any"
+
+	// Mountpoint verification settings (checkType "mountinfo", or any check type with RequireMountpoint)
+	RequireMountpoint bool     // Verify path is a mountpoint in /proc/self/mountinfo before the check
+	FSTypes           []string // Accepted filesystem types, e.g. "fuse.rclone" (optional, empty accepts any)
+
+	// Type-specific check settings: the mount's JSON object from the config file,
+	// decoded by the check type's health.CheckProvider (e.g. command for "exec")
+	Options json.RawMessage
+
+	// rclone remediation settings (watchdog remediation "remount" only)
+	RCRemote string // Remote to mount if rclone no longer lists the mount, e.g. "debrid:" (optional)
+}
+
+// FailureWindowConfig holds sliding-window failure counting settings. When set,
+// a mount is unhealthy while at least Failures of its last Size checks failed,
+// instead of after FailureThreshold consecutive failures.
+type FailureWindowConfig struct {
+	Size     int // Number of recent checks considered (default: 0 = consecutive counting)
+	Failures int // Failed checks within the window that make a mount unhealthy
+}
+
+// FailureWindow converts the settings into a health.FailureWindow.
+func (f FailureWindowConfig) FailureWindow() health.FailureWindow {
+	return health.FailureWindow{Size: f.Size, Failures: f.Failures}
+}
+
+// validate checks the failure window settings.
+func (f FailureWindowConfig) validate() error {
+	if f.Size == 0 && f.Failures == 0 {
+		return nil
+	}
+	if f.Size < 1 || f.Failures < 1 || f.Failures > f.Size {
+		return fmt.Errorf("failureWindow must have size >= 1 and 1 <= failures <= size (got size %d, failures %d)", f.Size, f.Failures)
+	}
+	return nil
+}
+
+// LatencyConfig holds a mount's latency thresholds. Checks that pass but take
+// longer than Warn mark the mount degraded; FailAfter checks in a row taking
+// longer than Fail count as failed checks.
+type LatencyConfig struct {
+	Warn      time.Duration // Duration above which a passing check marks the mount degraded (0 = disabled)
+	Fail      time.Duration // Duration above which a passing check counts as a failure (0 = disabled)
+	FailAfter int           // Consecutive checks slower than Fail before they count as failures (0 = 3)
+}
+
+// LatencyPolicy converts the settings into a health.LatencyPolicy.
+func (l LatencyConfig) LatencyPolicy() health.LatencyPolicy {
+	return health.LatencyPolicy{Warn: l.Warn, Fail: l.Fail, FailAfter: l.FailAfter}
+}
+
+// validate checks the latency thresholds. Thresholds must be below the read
+// timeout, since a check that takes longer times out and fails anyway.
+func (l LatencyConfig) validate(readTimeout time.Duration) error {
+	if l.Warn < 0 || l.Fail < 0 {
+		return fmt.Errorf("latency warn and fail must be >= 0")
+	}
+	if l.FailAfter < 0 {
+		return fmt.Errorf("latency failAfter must be >= 0 (got %d)", l.FailAfter)
+	}
+	if l.Warn > 0 && l.Fail > 0 && l.Fail <= l.Warn {
+		return fmt.Errorf("latency fail must be greater than warn (got warn %s, fail %s)", l.Warn, l.Fail)
+	}
+	if l.Warn >= readTimeout || l.Fail >= readTimeout {
+		return fmt.Errorf("latency warn and fail must be less than the read timeout (%s)", readTimeout)
+	}
+	return nil
+}
+
+// AdaptiveIntervalConfig holds state-dependent check interval settings. Zero
+// intervals fall back to CheckInterval.
+type AdaptiveIntervalConfig struct {
+	Degraded    time.Duration // Interval while a mount is degraded, unhealthy or recovering (default: 0 = checkInterval)
+	Stable      time.Duration // Interval after StableAfter consecutive healthy checks (default: 0 = checkInterval)
+	StableAfter int           // Consecutive passing checks before a healthy mount is stable (default: 10)
+}
+
+// AdaptiveInterval converts the settings into a health.AdaptiveInterval.
+func (a AdaptiveIntervalConfig) AdaptiveInterval() health.AdaptiveInterval {
+	return health.AdaptiveInterval{
+		Degraded:    a.Degraded,
+		Stable:      a.Stable,
+		StableAfter: a.StableAfter,
+	}
+}
+
+// validate checks the adaptive interval settings. Like checkInterval, each
+// interval must be longer than the read timeout.
+func (a AdaptiveIntervalConfig) validate(readTimeout time.Duration) error {
+	if a.StableAfter < 0 {
+		return fmt.Errorf("adaptiveInterval stableAfter must be >= 0")
+	}
+	intervals := []struct {
+		name     string
+		interval time.Duration
+	}{
+		{"degraded", a.Degraded},
+		{"stable", a.Stable},
+	}
+	for _, i := range intervals {
+		if i.interval != 0 && (i.interval < time.Second || i.interval <= readTimeout) {
+			return fmt.Errorf("adaptiveInterval %s must be >= 1 second and greater than the read timeout (got %s)", i.name, i.interval)
+		}
+	}
+	return nil
+}
+
+// FlapDetectionConfig holds flap detection settings.
+type FlapDetectionConfig struct {
+	Window    time.Duration // Period over which state transitions are counted (default: 1h)
+	Threshold int           // Transitions within Window that mark a mount as flapping (default: 0 = disabled)
+	Unhealthy bool          // Treat a flapping mount as unhealthy for watchdog purposes (default: false)
+}
+
+// FlapPolicy converts the settings into a health.FlapPolicy.
+func (f FlapDetectionConfig) FlapPolicy() health.FlapPolicy {
+	return health.FlapPolicy{
+		Window:    f.Window,
+		Threshold: f.Threshold,
+		Unhealthy: f.Unhealthy,
+	}
+}
+
+// validate checks the flap detection settings.
+func (f FlapDetectionConfig) validate() error {
+	if f.Threshold < 0 || f.Threshold == 1 {
+		return fmt.Errorf("flapDetection threshold must be 0 (disabled) or >= 2")
+	}
+	if f.Threshold > 0 && f.Window <= 0 {
+		return fmt.Errorf("flapDetection window must be > 0")
+	}
+	return nil
+}
+
+// FailureRule overrides how check failures of one error class are handled.
+type FailureRule struct {
+	Threshold    int  // Consecutive failures before unhealthy when the latest failure has this class (0 = use the mount's failureThreshold, 1 = immediately)
+	SkipWatchdog bool // Mark the mount unhealthy without triggering watchdog remediation or restart
+}
+
+// FailurePolicies converts the mount's failure rules into health.FailurePolicy
+// values keyed by failure reason. It returns nil if the mount has no rules.
+func (m MountConfig) FailurePolicies() map[health.FailureReason]health.FailurePolicy {
+	if len(m.FailureRules) == 0 {
+		return nil
+	}
+	policies := make(map[health.FailureReason]health.FailurePolicy, len(m.FailureRules))
+	for class, rule := range m.FailureRules {
+		reason, ok := health.ParseFailureReason(class)
+		if !ok {
+			continue // rejected by Validate
+		}
+		policies[reason] = health.FailurePolicy{
+			Threshold:    rule.Threshold,
+			SkipWatchdog: rule.SkipWatchdog,
+		}
+	}
+	return policies
+}
+
+// CheckConfig holds the settings of one check within a composite mount check.
+// Fields have the same meaning as the corresponding MountConfig fields.
+type CheckConfig struct {
+	CheckType         string // Health check type (required)
+	CanaryFile        string // Relative path to canary file within mount (optional, inherits mount canaryFile)
+	RequireMountpoint bool
+	FSTypes           []string
+	Options           json.RawMessage // The check's JSON object from the config file
+}
+
+// SingleCheck returns the mount's top-level check settings as a CheckConfig.
+func (m MountConfig) SingleCheck() CheckConfig {
+	return CheckConfig{
+		CheckType:         m.CheckType,
+		CanaryFile:        m.CanaryFile,
+		RequireMountpoint: m.RequireMountpoint,
+		FSTypes:           m.FSTypes,
+		Options:           m.Options,
+	}
+}
+
+// CheckSpec converts the check configuration into a health.CheckSpec for the mount
+// at mountPath, decoding its type-specific settings with the check type's provider.
+func (c CheckConfig) CheckSpec(mountPath string) (health.CheckSpec, error) {
+	spec := health.NewCheckSpec(mountPath, c.CanaryFile, c.CheckType)
+	spec.Mountpoint = health.MountpointSpec{
+		Require: c.RequireMountpoint,
+		FSTypes: c.FSTypes,
+	}
+	spec.Options = c.Options
+	if err := health.DecodeCheckSpec(&spec); err != nil {
+		return health.CheckSpec{}, err
+	}
+	return spec, nil
+}
+
+// RcloneEndpoint returns the rclone rc endpoint of the mount, used by watchdog
+// remediation: the mount's own rcUrl settings or, for composite mounts, those of
+// its first rclone-rc check. It returns false if the mount has no rc endpoint.
+func (m MountConfig) RcloneEndpoint() (health.RcloneSpec, bool) {
+	if spec, ok := rcloneSettings(m.Options); ok {
+		return spec, true
+	}
+	for _, cc := range m.Checks {
+		if cc.CheckType != health.CheckTypeRcloneRC {
+			continue
+		}
+		if spec, ok := rcloneSettings(cc.Options); ok {
+			return spec, true
+		}
+	}
+	return health.RcloneSpec{}, false
+}
+
+// rcloneSettings decodes rclone rc settings from a check's JSON object with the
+// rclone-rc provider. It returns false if the object has no valid rcUrl.
+func rcloneSettings(options json.RawMessage) (health.RcloneSpec, bool) {
+	provider, ok := health.LookupCheckType(health.CheckTypeRcloneRC)
+	if !ok || len(options) == 0 {
+		return health.RcloneSpec{}, false
+	}
+	settings, err := provider.Decode(options)
+	if err != nil {
+		return health.RcloneSpec{}, false
+	}
+	spec, ok := settings.(health.RcloneSpec)
+	return spec, ok
+}
+
+// checkModes lists the supported composite check modes.
+var checkModes = []string{health.CheckModeAll, health.CheckModeAny}
+
+// isValidCheckType reports whether a check type is registered with the health package.
+func isValidCheckType(checkType string) bool {
+	_, ok := health.LookupCheckType(checkType)
+	return ok
+}
+
+// checkTypeNames returns the human-readable list of registered check types for error messages.
+func checkTypeNames() string {
+	return strings.Join(health.CheckTypes(), ", ")
 }
 
 // WatchdogConfig holds configuration for the watchdog feature.
@@ -25,8 +284,21 @@ type WatchdogConfig struct {
 	MaxRetries          int           // Max API retry attempts before fallback (default: 3)
 	RetryBackoffInitial time.Duration // Initial retry delay for exponential backoff (default: 100ms)
 	RetryBackoffMax     time.Duration // Maximum retry delay cap (default: 10s)
+	Remediation         []string      // Remediation steps tried in order before restart: "vfs-refresh", "remount", "lazy-unmount" (default: none)
+	RemediationChecks   int           // Failed checks to wait for after each remediation step (default: 1, 0 = 1)
 }
 
+// Watchdog remediation step names accepted in Watchdog.Remediation. The watchdog
+// package gives its steps the same names.
+const (
+	RemediationVFSRefresh  = "vfs-refresh"
+	RemediationRemount     = "remount"
+	RemediationLazyUnmount = "lazy-unmount"
+)
+
+// remediations lists the supported watchdog remediation steps.
+var remediations = []string{RemediationVFSRefresh, RemediationRemount, RemediationLazyUnmount}
+
 // Config holds all runtime configuration for the mount monitor.
 type Config struct {
 	// Config file tracking
@@ -44,11 +316,24 @@ type Config struct {
 	ReadTimeout     time.Duration // Timeout for canary file read
 	ShutdownTimeout time.Duration // Max time for graceful shutdown
 
+	// Scheduling configuration
+	MaxConcurrentChecks int  // Maximum mount checks running at once (default: 4, 0 = no limit)
+	IsolateProbes       bool // Default for running checks in a subprocess that is killed on timeout
+
+	AdaptiveInterval AdaptiveIntervalConfig // Default state-dependent check intervals for all mounts
+
 	// Failure threshold configuration
-	FailureThreshold int // Default consecutive failures before unhealthy
+	FailureThreshold  int // Default consecutive failures before unhealthy
+	RecoveryThreshold int // Default consecutive passing checks before a failing mount is healthy again (default: 1, 0 = 1)
+
+	FailureWindow FailureWindowConfig // Default sliding-window failure counting (replaces FailureThreshold if set)
+
+	// Flap detection configuration
+	FlapDetection FlapDetectionConfig // Default flap detection settings for all mounts
 
 	// Server configuration
-	HTTPPort int // Port for health endpoints
+	HTTPPort      int    // Port for health endpoints
+	APITokensFile string // File with bearer tokens for the HTTP API (empty = admin endpoints disabled)
 
 	// Logging configuration
 	LogLevel  string // debug, info, warn, error
@@ -61,22 +346,31 @@ type Config struct {
 // DefaultConfig returns a Config with sensible defaults.
 func DefaultConfig() *Config {
 	return &Config{
-		ConfigFile:       "",
-		Mounts:           []MountConfig{},
-		CanaryFile:       ".health-check",
-		CheckInterval:    30 * time.Second,
-		ReadTimeout:      5 * time.Second,
-		ShutdownTimeout:  30 * time.Second,
-		FailureThreshold: 3,
-		HTTPPort:         8080,
-		LogLevel:         "info",
-		LogFormat:        "json",
+		ConfigFile:          "",
+		Mounts:              []MountConfig{},
+		CanaryFile:          ".health-check",
+		CheckInterval:       30 * time.Second,
+		ReadTimeout:         5 * time.Second,
+		ShutdownTimeout:     30 * time.Second,
+		MaxConcurrentChecks: 4,
+		FailureThreshold:    3,
+		RecoveryThreshold:   1,
+		FlapDetection: FlapDetectionConfig{
+			Window: time.Hour,
+		},
+		AdaptiveInterval: AdaptiveIntervalConfig{
+			StableAfter: 10,
+		},
+		HTTPPort:  8080,
+		LogLevel:  "info",
+		LogFormat: "json",
 		Watchdog: WatchdogConfig{
 			Enabled:             false,
 			RestartDelay:        0,
 			MaxRetries:          3,
 			RetryBackoffInitial: 100 * time.Millisecond,
 			RetryBackoffMax:     10 * time.Second,
+			RemediationChecks:   1,
 		},
 	}
 }
@@ -133,6 +427,7 @@ func (c *Config) Validate() error {
 	}
 
 	// Validate individual mount configs
+	names := make(map[string]int) // Mount name to index of its first mount
 	for i, m := range c.Mounts {
 		if m.Path == "" {
 			if m.Name != "" {
@@ -141,6 +436,16 @@ func (c *Config) Validate() error {
 				result = multierror.Append(result, fmt.Errorf("mount[%d]: path is required", i))
 			}
 		}
+		if m.Name == health.CheckAllName {
+			result = multierror.Append(result, fmt.Errorf("mount[%d]: name %q is reserved for the endpoint that checks every mount", i, m.Name))
+		}
+		if m.Name != "" {
+			if first, ok := names[m.Name]; ok {
+				result = multierror.Append(result, fmt.Errorf("mount[%d]: name %q is already used by mount[%d]", i, m.Name, first))
+			} else {
+				names[m.Name] = i
+			}
+		}
 		if m.FailureThreshold < 0 {
 			if m.Name != "" {
 				result = multierror.Append(result, fmt.Errorf("mount[%d] %q: failureThreshold must be >= 0", i, m.Name))
@@ -148,11 +453,45 @@ func (c *Config) Validate() error {
 				result = multierror.Append(result, fmt.Errorf("mount[%d]: failureThreshold must be >= 0", i))
 			}
 		}
-		if m.CheckType != "" && m.CheckType != "canary" && m.CheckType != "directory" {
+		if err := validateFailureRules(m.FailureRules); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s: %w", mountLabel(i, m.Name), err))
+		}
+		if m.RecoveryThreshold < 0 {
+			result = multierror.Append(result, fmt.Errorf("%s: recoveryThreshold must be >= 0", mountLabel(i, m.Name)))
+		}
+		if err := m.FailureWindow.validate(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s: %w", mountLabel(i, m.Name), err))
+		}
+		if err := m.FlapDetection.validate(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s: %w", mountLabel(i, m.Name), err))
+		}
+		if err := m.Latency.validate(c.ReadTimeout); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s: %w", mountLabel(i, m.Name), err))
+		}
+		if err := m.AdaptiveInterval.validate(c.ReadTimeout); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s: %w", mountLabel(i, m.Name), err))
+		}
+		if m.CheckType != "" && !isValidCheckType(m.CheckType) {
 			if m.Name != "" {
-				result = multierror.Append(result, fmt.Errorf("mount[%d] %q: checkType must be one of: canary, directory (got %q)", i, m.Name, m.CheckType))
+				result = multierror.Append(result, fmt.Errorf("mount[%d] %q: checkType must be one of: %s (got %q)", i, m.Name, checkTypeNames(), m.CheckType))
 			} else {
-				result = multierror.Append(result, fmt.Errorf("mount[%d]: checkType must be one of: canary, directory (got %q)", i, m.CheckType))
+				result = multierror.Append(result, fmt.Errorf("mount[%d]: checkType must be one of: %s (got %q)", i, checkTypeNames(), m.CheckType))
+			}
+		}
+		if m.CheckType != "" && isValidCheckType(m.CheckType) {
+			if err := validateCheckSettings(m.Path, m.SingleCheck()); err != nil {
+				result = multierror.Append(result, fmt.Errorf("%s: %w", mountLabel(i, m.Name), err))
+			}
+		}
+		if err := validateMountpoint(m.CheckType, m.RequireMountpoint, m.FSTypes); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s: %w", mountLabel(i, m.Name), err))
+		}
+		if err := validateComposite(m.CheckType, m.CheckMode, m.RequireMountpoint, m.FSTypes, len(m.Checks)); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s: %w", mountLabel(i, m.Name), err))
+		}
+		for j, cc := range m.Checks {
+			if err := validateCheck(m.Path, cc); err != nil {
+				result = multierror.Append(result, fmt.Errorf("%s checks[%d]: %w", mountLabel(i, m.Name), j, err))
 			}
 		}
 	}
@@ -180,6 +519,26 @@ func (c *Config) Validate() error {
 			result = multierror.Append(result, fmt.Errorf("failure threshold must be >= 1"))
 		}
 
+		if c.RecoveryThreshold < 0 {
+			result = multierror.Append(result, fmt.Errorf("recovery threshold must be >= 0"))
+		}
+
+		if c.MaxConcurrentChecks < 0 {
+			result = multierror.Append(result, fmt.Errorf("max concurrent checks must be >= 0"))
+		}
+
+		if err := c.FailureWindow.validate(); err != nil {
+			result = multierror.Append(result, err)
+		}
+
+		if err := c.FlapDetection.validate(); err != nil {
+			result = multierror.Append(result, err)
+		}
+
+		if err := c.AdaptiveInterval.validate(c.ReadTimeout); err != nil {
+			result = multierror.Append(result, err)
+		}
+
 		if c.HTTPPort < 1 || c.HTTPPort > 65535 {
 			result = multierror.Append(result, fmt.Errorf("HTTP port must be between 1 and 65535"))
 		}
@@ -209,7 +568,105 @@ func (c *Config) Validate() error {
 		if c.Watchdog.RetryBackoffMax < c.Watchdog.RetryBackoffInitial {
 			result = multierror.Append(result, fmt.Errorf("watchdog retry backoff max must be >= retry backoff initial"))
 		}
+		if c.Watchdog.RemediationChecks < 0 {
+			result = multierror.Append(result, fmt.Errorf("watchdog remediation checks must be >= 0"))
+		}
+		seen := make(map[string]bool)
+		for _, step := range c.Watchdog.Remediation {
+			if !slices.Contains(remediations, step) {
+				result = multierror.Append(result, fmt.Errorf("watchdog remediation steps must be one of: %s (got %q)", strings.Join(remediations, ", "), step))
+			} else if seen[step] {
+				result = multierror.Append(result, fmt.Errorf("watchdog remediation step %q is listed more than once", step))
+			}
+			seen[step] = true
+		}
 	}
 
 	return result.ErrorOrNil()
 }
+
+// mountLabel returns the identifier used for a mount in validation errors.
+func mountLabel(i int, name string) string {
+	if name != "" {
+		return fmt.Sprintf("mount[%d] %q", i, name)
+	}
+	return fmt.Sprintf("mount[%d]", i)
+}
+
+// validateMountpoint checks the mountpoint verification settings of a mount.
+func validateMountpoint(checkType string, require bool, fsTypes []string) error {
+	if len(fsTypes) == 0 {
+		return nil
+	}
+	if checkType != health.CheckTypeMountinfo && !require {
+		return fmt.Errorf("fsTypes requires checkType mountinfo or requireMountpoint")
+	}
+	for _, t := range fsTypes {
+		if strings.TrimSpace(t) == "" {
+			return fmt.Errorf("fsTypes must not contain empty entries")
+		}
+	}
+	return nil
+}
+
+// validateFailureRules checks that failure rules are keyed by known error classes
+// and have valid thresholds.
+func validateFailureRules(rules map[string]FailureRule) error {
+	classes := make([]string, 0, len(rules))
+	for class := range rules {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+
+	for _, class := range classes {
+		if reason, ok := health.ParseFailureReason(class); !ok || reason == health.ReasonNone {
+			return fmt.Errorf("failureRules: error class must be one of: %s (got %q)", strings.Join(health.FailureReasonNames(), ", "), class)
+		}
+		if rules[class].Threshold < 0 {
+			return fmt.Errorf("failureRules %q: threshold must be >= 0", class)
+		}
+	}
+	return nil
+}
+
+// validateComposite checks the composite check settings of a mount.
+// A mount runs either a single checkType or a list of checks, never both.
+func validateComposite(checkType, checkMode string, requireMountpoint bool, fsTypes []string, numChecks int) error {
+	if checkMode != "" && !slices.Contains(checkModes, checkMode) {
+		return fmt.Errorf("checkMode must be one of: %s (got %q)", strings.Join(checkModes, ", "), checkMode)
+	}
+	if numChecks == 0 {
+		if checkMode != "" {
+			return fmt.Errorf("checkMode requires checks")
+		}
+		return nil
+	}
+	if checkType != "" {
+		return fmt.Errorf("checkType and checks are mutually exclusive")
+	}
+	if requireMountpoint || len(fsTypes) > 0 {
+		return fmt.Errorf("requireMountpoint and fsTypes must be set on individual checks when checks is used")
+	}
+	return nil
+}
+
+// validateCheck checks the settings of one check within a composite mount check.
+func validateCheck(mountPath string, cc CheckConfig) error {
+	if cc.CheckType == "" {
+		return fmt.Errorf("checkType is required")
+	}
+	if !isValidCheckType(cc.CheckType) {
+		return fmt.Errorf("checkType must be one of: %s (got %q)", checkTypeNames(), cc.CheckType)
+	}
+	if err := validateCheckSettings(mountPath, cc); err != nil {
+		return err
+	}
+	return validateMountpoint(cc.CheckType, cc.RequireMountpoint, cc.FSTypes)
+}
+
+// validateCheckSettings checks the type-specific settings of a registered check type
+// by decoding them with the check type's health.CheckProvider.
+func validateCheckSettings(mountPath string, cc CheckConfig) error {
+	_, err := cc.CheckSpec(mountPath)
+	return err
+}
@@ -1,10 +1,13 @@
 package config_test
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cscheib/debrid-mount-monitor/internal/config"
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
 	"github.com/matryer/is"
 	"go.uber.org/goleak"
 )
@@ -110,6 +113,171 @@ func TestConfigValidation_InvalidFailureThreshold(t *testing.T) {
 	is.True(err != nil) // failure threshold < 1 should error
 }
 
+func TestConfigValidation_InvalidRecoveryThreshold(t *testing.T) {
+	is := is.New(t)
+
+	cfg := config.DefaultConfig()
+	cfg.Mounts = []config.MountConfig{testMount()}
+	cfg.RecoveryThreshold = -1
+
+	is.True(cfg.Validate() != nil) // negative global recovery threshold should error
+
+	cfg.RecoveryThreshold = 1
+	cfg.Mounts[0].RecoveryThreshold = -1
+
+	is.True(cfg.Validate() != nil) // negative mount recovery threshold should error
+}
+
+func TestConfigValidation_InvalidMaxConcurrentChecks(t *testing.T) {
+	is := is.New(t)
+
+	cfg := config.DefaultConfig()
+	cfg.Mounts = []config.MountConfig{testMount()}
+	cfg.MaxConcurrentChecks = 0
+
+	is.NoErr(cfg.Validate()) // 0 means no limit
+
+	cfg.MaxConcurrentChecks = -1
+
+	is.True(cfg.Validate() != nil) // negative max concurrent checks should error
+}
+
+func TestConfigValidation_FailureWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  config.FailureWindowConfig
+		wantErr bool
+	}{
+		{"disabled", config.FailureWindowConfig{}, false},
+		{"enabled", config.FailureWindowConfig{Size: 10, Failures: 8}, false},
+		{"all checks", config.FailureWindowConfig{Size: 3, Failures: 3}, false},
+		{"missing failures", config.FailureWindowConfig{Size: 10}, true},
+		{"failures above size", config.FailureWindowConfig{Size: 3, Failures: 4}, true},
+		{"negative size", config.FailureWindowConfig{Size: -1, Failures: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			mount := testMount()
+			mount.FailureWindow = tt.window
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid failure window should error
+			} else {
+				is.NoErr(err) // valid failure window should pass
+			}
+		})
+	}
+}
+
+func TestConfigValidation_Latency(t *testing.T) {
+	tests := []struct {
+		name    string
+		latency config.LatencyConfig
+		wantErr bool
+	}{
+		{"disabled", config.LatencyConfig{}, false},
+		{"warn only", config.LatencyConfig{Warn: 3 * time.Second}, false},
+		{"fail only", config.LatencyConfig{Fail: 4 * time.Second}, false},
+		{"warn and fail", config.LatencyConfig{Warn: 2 * time.Second, Fail: 4 * time.Second}, false},
+		{"fail after", config.LatencyConfig{Fail: 4 * time.Second, FailAfter: 5}, false},
+		{"negative warn", config.LatencyConfig{Warn: -time.Second}, true},
+		{"negative fail after", config.LatencyConfig{Fail: 4 * time.Second, FailAfter: -1}, true},
+		{"fail not above warn", config.LatencyConfig{Warn: 3 * time.Second, Fail: 3 * time.Second}, true},
+		{"warn at read timeout", config.LatencyConfig{Warn: 5 * time.Second}, true},
+		{"fail above read timeout", config.LatencyConfig{Fail: 6 * time.Second}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			mount := testMount()
+			mount.Latency = tt.latency
+			cfg := config.DefaultConfig() // 5s read timeout
+			cfg.Mounts = []config.MountConfig{mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid latency thresholds should error
+			} else {
+				is.NoErr(err) // valid latency thresholds should pass
+			}
+		})
+	}
+}
+
+func TestConfigValidation_AdaptiveInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		adaptive config.AdaptiveIntervalConfig
+		wantErr  bool
+	}{
+		{"disabled", config.AdaptiveIntervalConfig{}, false},
+		{"degraded", config.AdaptiveIntervalConfig{Degraded: 10 * time.Second}, false},
+		{"stable", config.AdaptiveIntervalConfig{Stable: 5 * time.Minute, StableAfter: 10}, false},
+		{"degraded below read timeout", config.AdaptiveIntervalConfig{Degraded: 2 * time.Second}, true},
+		{"stable below one second", config.AdaptiveIntervalConfig{Stable: 500 * time.Millisecond}, true},
+		{"negative degraded", config.AdaptiveIntervalConfig{Degraded: -time.Minute}, true},
+		{"negative stableAfter", config.AdaptiveIntervalConfig{Stable: 5 * time.Minute, StableAfter: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			mount := testMount()
+			mount.AdaptiveInterval = tt.adaptive
+			cfg := config.DefaultConfig() // 5s read timeout
+			cfg.Mounts = []config.MountConfig{mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid adaptive interval should error
+			} else {
+				is.NoErr(err) // valid adaptive interval should pass
+			}
+		})
+	}
+}
+
+func TestConfigValidation_FlapDetection(t *testing.T) {
+	tests := []struct {
+		name    string
+		flap    config.FlapDetectionConfig
+		wantErr bool
+	}{
+		{"disabled", config.FlapDetectionConfig{}, false},
+		{"enabled", config.FlapDetectionConfig{Window: time.Hour, Threshold: 10, Unhealthy: true}, false},
+		{"threshold of one", config.FlapDetectionConfig{Window: time.Hour, Threshold: 1}, true},
+		{"negative threshold", config.FlapDetectionConfig{Window: time.Hour, Threshold: -1}, true},
+		{"missing window", config.FlapDetectionConfig{Threshold: 5}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			mount := testMount()
+			mount.FlapDetection = tt.flap
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid flap detection should error
+			} else {
+				is.NoErr(err) // valid flap detection should pass
+			}
+		})
+	}
+}
+
 func TestConfigValidation_InvalidHTTPPort(t *testing.T) {
 	tests := []struct {
 		name string
@@ -219,6 +387,38 @@ func TestConfigValidation_MountWithNameMissingPath(t *testing.T) {
 	is.True(err != nil) // named mount without path should error
 }
 
+// TestConfigValidation_MountReservedName tests that a mount cannot take the name
+// that stands for every mount in the check endpoint
+func TestConfigValidation_MountReservedName(t *testing.T) {
+	is := is.New(t)
+
+	cfg := config.DefaultConfig()
+	cfg.Mounts = []config.MountConfig{{Name: "check", Path: "/mnt/test"}}
+
+	err := cfg.Validate()
+	is.True(err != nil)                                // reserved name should error
+	is.True(strings.Contains(err.Error(), "reserved")) // error names the problem
+}
+
+// TestConfigValidation_DuplicateMountName tests that two mounts cannot share a
+// name, since the check endpoint looks mounts up by name
+func TestConfigValidation_DuplicateMountName(t *testing.T) {
+	is := is.New(t)
+
+	cfg := config.DefaultConfig()
+	cfg.Mounts = []config.MountConfig{
+		{Name: "movies", Path: "/mnt/a"},
+		{Path: "/mnt/b"},
+		{Path: "/mnt/c"}, // unnamed mounts may coexist
+		{Name: "movies", Path: "/mnt/d"},
+	}
+
+	err := cfg.Validate()
+	is.True(err != nil)                                                                           // duplicate name should error
+	is.True(strings.Contains(err.Error(), `mount[3]: name "movies" is already used by mount[0]`)) // error names both mounts
+	is.Equal(strings.Count(err.Error(), "already used"), 1)                                       // unnamed mounts are not duplicates
+}
+
 // TestConfigValidation_MountNegativeThreshold tests mount with negative failure threshold
 func TestConfigValidation_MountNegativeThreshold(t *testing.T) {
 	is := is.New(t)
@@ -272,6 +472,7 @@ func TestConfigValidation_MountCheckType(t *testing.T) {
 		{"default", "", false},
 		{"canary", "canary", false},
 		{"directory", "directory", false},
+		{"content", "content", true}, // content requires an expectation
 		{"invalid", "http", true},
 	}
 
@@ -292,6 +493,392 @@ func TestConfigValidation_MountCheckType(t *testing.T) {
 	}
 }
 
+func TestConfigValidation_ContentCheck(t *testing.T) {
+	validSHA := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name    string
+		mount   config.MountConfig
+		wantErr bool
+	}{
+		{"exact content", config.MountConfig{Path: "/mnt/test", CheckType: "content", Options: json.RawMessage(`{"expectedContent": "ok"}`)}, false},
+		{"min size", config.MountConfig{Path: "/mnt/test", CheckType: "content", Options: json.RawMessage(`{"minSize": 1}`)}, false},
+		{"sha256", config.MountConfig{Path: "/mnt/test", CheckType: "content", Options: json.RawMessage(`{"expectedSha256": "` + validSHA + `"}`)}, false},
+		{"no expectation", config.MountConfig{Path: "/mnt/test", CheckType: "content"}, true},
+		{"negative min size", config.MountConfig{Path: "/mnt/test", CheckType: "content", Options: json.RawMessage(`{"minSize": -1}`)}, true},
+		{"short sha256", config.MountConfig{Path: "/mnt/test", CheckType: "content", Options: json.RawMessage(`{"expectedSha256": "abcd"}`)}, true},
+		{"non-hex sha256", config.MountConfig{Path: "/mnt/test", CheckType: "content", Options: json.RawMessage(`{"expectedSha256": "` + strings.Repeat("zz", 32) + `"}`)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{tt.mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid content check should error
+			} else {
+				is.NoErr(err) // valid content check should pass
+			}
+		})
+	}
+}
+
+func TestConfigValidation_WriteCheckProbeDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		probeDir string
+		wantErr  bool
+	}{
+		{"default", "", false},
+		{"subdirectory", ".probes", false},
+		{"nested", "a/b", false},
+		{"absolute", "/tmp", true},
+		{"escapes mount", "../other", true},
+		{"parent", "..", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{{Path: "/mnt/test", CheckType: "write", Options: json.RawMessage(`{"probeDir": "` + tt.probeDir + `"}`)}}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid probeDir should error
+			} else {
+				is.NoErr(err) // valid probeDir should pass
+			}
+		})
+	}
+}
+
+func TestConfigValidation_RangeReadCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		mount   config.MountConfig
+		wantErr bool
+	}{
+		{"file", config.MountConfig{Path: "/mnt/test", CheckType: "range-read", Options: json.RawMessage(`{"rangeFile": "movies/a.mkv"}`)}, false},
+		{"glob", config.MountConfig{Path: "/mnt/test", CheckType: "range-read", Options: json.RawMessage(`{"rangeGlob": "movies/*/*.mkv", "rangeBytes": 1048576}`)}, false},
+		{"neither", config.MountConfig{Path: "/mnt/test", CheckType: "range-read"}, true},
+		{"both", config.MountConfig{Path: "/mnt/test", CheckType: "range-read", Options: json.RawMessage(`{"rangeFile": "a.mkv", "rangeGlob": "*.mkv"}`)}, true},
+		{"absolute file", config.MountConfig{Path: "/mnt/test", CheckType: "range-read", Options: json.RawMessage(`{"rangeFile": "/etc/passwd"}`)}, true},
+		{"escaping glob", config.MountConfig{Path: "/mnt/test", CheckType: "range-read", Options: json.RawMessage(`{"rangeGlob": "../*"}`)}, true},
+		{"bad glob", config.MountConfig{Path: "/mnt/test", CheckType: "range-read", Options: json.RawMessage(`{"rangeGlob": "movies/[a-"}`)}, true},
+		{"negative bytes", config.MountConfig{Path: "/mnt/test", CheckType: "range-read", Options: json.RawMessage(`{"rangeFile": "a.mkv", "rangeBytes": -1}`)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{tt.mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid range-read check should error
+			} else {
+				is.NoErr(err) // valid range-read check should pass
+			}
+		})
+	}
+}
+
+func TestConfigValidation_ListingCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		mount   config.MountConfig
+		wantErr bool
+	}{
+		{"defaults", config.MountConfig{Path: "/mnt/test", CheckType: "listing"}, false},
+		{"min entries and dirs", config.MountConfig{Path: "/mnt/test", CheckType: "listing", Options: json.RawMessage(`{"minEntries": 2, "expectedDirs": ["movies/", "shows"]}`)}, false},
+		{"negative min entries", config.MountConfig{Path: "/mnt/test", CheckType: "listing", Options: json.RawMessage(`{"minEntries": -1}`)}, true},
+		{"nested dir", config.MountConfig{Path: "/mnt/test", CheckType: "listing", Options: json.RawMessage(`{"expectedDirs": ["media/movies"]}`)}, true},
+		{"parent dir", config.MountConfig{Path: "/mnt/test", CheckType: "listing", Options: json.RawMessage(`{"expectedDirs": [".."]}`)}, true},
+		{"empty dir name", config.MountConfig{Path: "/mnt/test", CheckType: "listing", Options: json.RawMessage(`{"expectedDirs": [""]}`)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{tt.mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid listing check should error
+			} else {
+				is.NoErr(err) // valid listing check should pass
+			}
+		})
+	}
+}
+
+func TestConfigValidation_ExecCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		mount   config.MountConfig
+		wantErr bool
+	}{
+		{"command", config.MountConfig{Path: "/mnt/test", CheckType: "exec", Options: json.RawMessage(`{"command": "/usr/local/bin/check-zurg"}`)}, false},
+		{"args env and codes", config.MountConfig{Path: "/mnt/test", CheckType: "exec", Options: json.RawMessage(`{"command": "rclone", "args": ["rc", "vfs/stats"], "env": {"RCLONE_RC_ADDR": "localhost:5572"}, "successExitCodes": [0]}`)}, false},
+		{"missing command", config.MountConfig{Path: "/mnt/test", CheckType: "exec"}, true},
+		{"invalid env key", config.MountConfig{Path: "/mnt/test", CheckType: "exec", Options: json.RawMessage(`{"command": "probe", "env": {"": "x"}}`)}, true},
+		{"invalid exit code", config.MountConfig{Path: "/mnt/test", CheckType: "exec", Options: json.RawMessage(`{"command": "probe", "successExitCodes": [-1]}`)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{tt.mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid exec check should error
+			} else {
+				is.NoErr(err) // valid exec check should pass
+			}
+		})
+	}
+}
+
+func TestConfigValidation_HTTPCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		mount   config.MountConfig
+		wantErr bool
+	}{
+		{"get", config.MountConfig{Path: "/mnt/test", CheckType: "http", Options: json.RawMessage(`{"url": "http://zurg:9999/dav/"}`)}, false},
+		{"propfind with auth", config.MountConfig{Path: "/mnt/test", CheckType: "http", Options: json.RawMessage(`{"url": "http://zurg:9999/dav/", "method": "PROPFIND", "username": "plex", "password": "secret", "expectedStatus": [207]}`)}, false},
+		{"missing url", config.MountConfig{Path: "/mnt/test", CheckType: "http"}, true},
+		{"invalid method", config.MountConfig{Path: "/mnt/test", CheckType: "http", Options: json.RawMessage(`{"url": "http://zurg/", "method": "DELETE"}`)}, true},
+		{"in composite", config.MountConfig{Path: "/mnt/test", Checks: []config.CheckConfig{{CheckType: "http", Options: json.RawMessage(`{"url": "http://zurg/"}`)}, {CheckType: "canary"}}}, false},
+		{"invalid in composite", config.MountConfig{Path: "/mnt/test", Checks: []config.CheckConfig{{CheckType: "http", Options: json.RawMessage(`{"url": "zurg"}`)}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{tt.mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid http check should error
+			} else {
+				is.NoErr(err) // valid http check should pass
+			}
+		})
+	}
+}
+
+func TestConfigValidation_RcloneRCCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		mount   config.MountConfig
+		wantErr bool
+	}{
+		{"valid", config.MountConfig{Path: "/mnt/test", CheckType: "rclone-rc", Options: json.RawMessage(`{"rcUrl": "http://rclone:5572"}`)}, false},
+		{"with auth and limits", config.MountConfig{Path: "/mnt/test", CheckType: "rclone-rc", Options: json.RawMessage(`{"rcUrl": "http://rclone:5572", "rcUsername": "rc", "rcPassword": "secret", "rcMountPoint": "/data/debrid", "maxUploadQueue": 20}`)}, false},
+		{"missing rc url", config.MountConfig{Path: "/mnt/test", CheckType: "rclone-rc"}, true},
+		{"negative max upload queue", config.MountConfig{Path: "/mnt/test", CheckType: "rclone-rc", Options: json.RawMessage(`{"rcUrl": "http://rclone:5572", "maxUploadQueue": -1}`)}, true},
+		{"in composite", config.MountConfig{Path: "/mnt/test", Checks: []config.CheckConfig{{CheckType: "rclone-rc", Options: json.RawMessage(`{"rcUrl": "http://rclone:5572"}`)}, {CheckType: "canary"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{tt.mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid rclone-rc check should error
+			} else {
+				is.NoErr(err) // valid rclone-rc check should pass
+			}
+		})
+	}
+}
+
+func TestConfigValidation_WatchdogRemediation(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []string
+		checks  int
+		wantErr bool
+	}{
+		{"none", nil, 1, false},
+		{"both steps", []string{"vfs-refresh", "remount"}, 2, false},
+		{"lazy unmount", []string{"lazy-unmount", "remount"}, 1, false},
+		{"unknown step", []string{"vfs-forget"}, 1, true},
+		{"duplicate step", []string{"remount", "remount"}, 1, true},
+		{"negative checks", []string{"remount"}, -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{testMount()}
+			cfg.Watchdog.Remediation = tt.steps
+			cfg.Watchdog.RemediationChecks = tt.checks
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid remediation should error
+			} else {
+				is.NoErr(err) // valid remediation should pass
+			}
+		})
+	}
+}
+
+func TestMountConfig_RcloneEndpoint(t *testing.T) {
+	is := is.New(t)
+
+	_, ok := testMount().RcloneEndpoint()
+	is.True(!ok) // no rc endpoint by default
+
+	single := config.MountConfig{Path: "/mnt/test", CheckType: "canary", Options: json.RawMessage(`{"rcUrl": "http://rclone:5572", "rcMountPoint": "/data/test"}`)}
+	rc, ok := single.RcloneEndpoint()
+	is.True(ok)                            // mount-level rc endpoint
+	is.Equal(rc.URL, "http://rclone:5572") // rcUrl
+	is.Equal(rc.MountPoint, "/data/test")  // rcMountPoint
+
+	composite := config.MountConfig{Path: "/mnt/test", Checks: []config.CheckConfig{
+		{CheckType: "canary"},
+		{CheckType: "rclone-rc", Options: json.RawMessage(`{"rcUrl": "http://rclone:5572", "rcUsername": "rc"}`)},
+	}}
+	rc, ok = composite.RcloneEndpoint()
+	is.True(ok)                 // rc endpoint from the rclone-rc check
+	is.Equal(rc.Username, "rc") // rcUsername
+}
+
+func TestConfigValidation_FailureRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   map[string]config.FailureRule
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"immediate and alert only", map[string]config.FailureRule{"not_connected": {Threshold: 1}, "permission": {SkipWatchdog: true}}, false},
+		{"unknown class", map[string]config.FailureRule{"enotconn": {Threshold: 1}}, true},
+		{"none class", map[string]config.FailureRule{"none": {Threshold: 1}}, true},
+		{"negative threshold", map[string]config.FailureRule{"timeout": {Threshold: -1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			mount := testMount()
+			mount.FailureRules = tt.rules
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid failure rules should error
+			} else {
+				is.NoErr(err) // valid failure rules should pass
+			}
+		})
+	}
+}
+
+func TestMountConfig_FailurePolicies(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(testMount().FailurePolicies(), nil) // no rules by default
+
+	mount := testMount()
+	mount.FailureRules = map[string]config.FailureRule{
+		"stale":      {Threshold: 1},
+		"permission": {SkipWatchdog: true},
+	}
+	policies := mount.FailurePolicies()
+	is.Equal(len(policies), 2)                                                            // one policy per rule
+	is.Equal(policies[health.ReasonStale], health.FailurePolicy{Threshold: 1})            // immediate
+	is.Equal(policies[health.ReasonPermission], health.FailurePolicy{SkipWatchdog: true}) // alert only
+}
+
+func TestConfigValidation_Mountpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		mount   config.MountConfig
+		wantErr bool
+	}{
+		{"mountinfo check", config.MountConfig{Path: "/mnt/test", CheckType: "mountinfo"}, false},
+		{"mountinfo with fstypes", config.MountConfig{Path: "/mnt/test", CheckType: "mountinfo", FSTypes: []string{"fuse.rclone"}}, false},
+		{"require on canary", config.MountConfig{Path: "/mnt/test", RequireMountpoint: true, FSTypes: []string{"nfs4"}}, false},
+		{"fstypes without verification", config.MountConfig{Path: "/mnt/test", CheckType: "directory", FSTypes: []string{"nfs4"}}, true},
+		{"empty fstype", config.MountConfig{Path: "/mnt/test", CheckType: "mountinfo", FSTypes: []string{""}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{tt.mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid mountpoint settings should error
+			} else {
+				is.NoErr(err) // valid mountpoint settings should pass
+			}
+		})
+	}
+}
+
+func TestConfigValidation_CompositeChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		mount   config.MountConfig
+		wantErr bool
+	}{
+		{"all mode", config.MountConfig{Path: "/mnt/test", Checks: []config.CheckConfig{{CheckType: "canary"}, {CheckType: "mountinfo"}}}, false},
+		{"any mode", config.MountConfig{Path: "/mnt/test", CheckMode: "any", Checks: []config.CheckConfig{{CheckType: "directory"}}}, false},
+		{"checkType and checks", config.MountConfig{Path: "/mnt/test", CheckType: "canary", Checks: []config.CheckConfig{{CheckType: "directory"}}}, true},
+		{"invalid mode", config.MountConfig{Path: "/mnt/test", CheckMode: "most", Checks: []config.CheckConfig{{CheckType: "directory"}}}, true},
+		{"mode without checks", config.MountConfig{Path: "/mnt/test", CheckType: "canary", CheckMode: "all"}, true},
+		{"invalid sub-check type", config.MountConfig{Path: "/mnt/test", Checks: []config.CheckConfig{{CheckType: "bogus"}}}, true},
+		{"invalid sub-check settings", config.MountConfig{Path: "/mnt/test", Checks: []config.CheckConfig{{CheckType: "range-read"}}}, true},
+		{"mount-level requireMountpoint", config.MountConfig{Path: "/mnt/test", RequireMountpoint: true, Checks: []config.CheckConfig{{CheckType: "directory"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cfg := config.DefaultConfig()
+			cfg.Mounts = []config.MountConfig{tt.mount}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				is.True(err != nil) // invalid composite settings should error
+			} else {
+				is.NoErr(err) // valid composite settings should pass
+			}
+		})
+	}
+}
+
 // T004: Test that InitContainerMode field exists and defaults to false
 func TestDefaultConfig_InitContainerMode(t *testing.T) {
 	is := is.New(t)
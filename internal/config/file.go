@@ -8,6 +8,8 @@ import (
 	"os"
 	"runtime"
 	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
 )
 
 const (
@@ -44,29 +46,184 @@ type FileWatchdogConfig struct {
 	MaxRetries          int      `json:"maxRetries,omitempty"`
 	RetryBackoffInitial Duration `json:"retryBackoffInitial,omitempty"`
 	RetryBackoffMax     Duration `json:"retryBackoffMax,omitempty"`
+	Remediation         []string `json:"remediation,omitempty"`
+	RemediationChecks   int      `json:"remediationChecks,omitempty"`
 }
 
 // FileConfig represents the JSON configuration file structure.
 type FileConfig struct {
-	CheckInterval    Duration           `json:"checkInterval,omitempty"`
-	ReadTimeout      Duration           `json:"readTimeout,omitempty"`
-	ShutdownTimeout  Duration           `json:"shutdownTimeout,omitempty"`
-	FailureThreshold int                `json:"failureThreshold,omitempty"`
-	HTTPPort         int                `json:"httpPort,omitempty"`
-	LogLevel         string             `json:"logLevel,omitempty"`
-	LogFormat        string             `json:"logFormat,omitempty"`
-	CanaryFile       string             `json:"canaryFile,omitempty"`
-	Mounts           []FileMountConfig  `json:"mounts,omitempty"`
-	Watchdog         FileWatchdogConfig `json:"watchdog,omitempty"`
+	CheckInterval       Duration                    `json:"checkInterval,omitempty"`
+	ReadTimeout         Duration                    `json:"readTimeout,omitempty"`
+	ShutdownTimeout     Duration                    `json:"shutdownTimeout,omitempty"`
+	FailureThreshold    int                         `json:"failureThreshold,omitempty"`
+	RecoveryThreshold   int                         `json:"recoveryThreshold,omitempty"`
+	FailureWindow       *FileFailureWindowConfig    `json:"failureWindow,omitempty"`
+	FlapDetection       *FileFlapDetectionConfig    `json:"flapDetection,omitempty"`
+	MaxConcurrentChecks *int                        `json:"maxConcurrentChecks,omitempty"` // nil = default, 0 = no limit
+	IsolateProbes       bool                        `json:"isolateProbes,omitempty"`
+	AdaptiveInterval    *FileAdaptiveIntervalConfig `json:"adaptiveInterval,omitempty"`
+	HTTPPort            int                         `json:"httpPort,omitempty"`
+	APITokensFile       string                      `json:"apiTokensFile,omitempty"`
+	LogLevel            string                      `json:"logLevel,omitempty"`
+	LogFormat           string                      `json:"logFormat,omitempty"`
+	CanaryFile          string                      `json:"canaryFile,omitempty"`
+	Mounts              []FileMountConfig           `json:"mounts,omitempty"`
+	Watchdog            FileWatchdogConfig          `json:"watchdog,omitempty"`
 }
 
 // FileMountConfig represents per-mount configuration in the JSON file.
+// Check holds the mount's single check settings, which are fields of the mount object.
 type FileMountConfig struct {
-	Name             string `json:"name,omitempty"`
-	Path             string `json:"path"`
-	CanaryFile       string `json:"canaryFile,omitempty"`
-	CheckType        string `json:"checkType,omitempty"`
-	FailureThreshold int    `json:"failureThreshold,omitempty"` // 0 = use global default, >= 1 = explicit value
+	Check             FileCheckConfig             `json:"-"`
+	Name              string                      `json:"name,omitempty"`
+	Path              string                      `json:"path"`
+	FailureThreshold  int                         `json:"failureThreshold,omitempty"`  // 0 = use global default, >= 1 = explicit value
+	RecoveryThreshold int                         `json:"recoveryThreshold,omitempty"` // 0 = use global default, >= 1 = explicit value
+	FailureWindow     *FileFailureWindowConfig    `json:"failureWindow,omitempty"`     // nil = use global settings
+	FlapDetection     *FileFlapDetectionConfig    `json:"flapDetection,omitempty"`     // nil = use global settings
+	Latency           *FileLatencyConfig          `json:"latency,omitempty"`           // nil = no latency thresholds
+	IsolateProbes     *bool                       `json:"isolateProbes,omitempty"`     // nil = use global setting
+	AdaptiveInterval  *FileAdaptiveIntervalConfig `json:"adaptiveInterval,omitempty"`  // nil = use global settings
+
+	FailureRules map[string]FileFailureRule `json:"failureRules,omitempty"` // Keyed by error class, e.g. "not_connected"
+
+	Checks    []FileCheckConfig `json:"checks,omitempty"`
+	CheckMode string            `json:"checkMode,omitempty"`
+
+	RCRemote string `json:"rcRemote,omitempty"` // Remote to mount when remediating (watchdog remediation "remount")
+}
+
+// UnmarshalJSON implements json.Unmarshaler for FileMountConfig. The mount
+// object is decoded twice: for the mount's own fields and for its single check.
+func (f *FileMountConfig) UnmarshalJSON(b []byte) error {
+	type fileMountConfig FileMountConfig // Without this method, so decoding does not recurse
+	if err := json.Unmarshal(b, (*fileMountConfig)(f)); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, &f.Check)
+}
+
+// FileFailureWindowConfig represents sliding-window failure counting settings in the JSON file.
+type FileFailureWindowConfig struct {
+	Size     int `json:"size"`
+	Failures int `json:"failures"`
+}
+
+// apply returns base overridden by the settings from the file.
+func (f *FileFailureWindowConfig) apply(base FailureWindowConfig) FailureWindowConfig {
+	if f == nil {
+		return base
+	}
+	return FailureWindowConfig{Size: f.Size, Failures: f.Failures}
+}
+
+// FileAdaptiveIntervalConfig represents state-dependent check interval settings in the JSON file.
+type FileAdaptiveIntervalConfig struct {
+	Degraded    Duration `json:"degraded,omitempty"`
+	Stable      Duration `json:"stable,omitempty"`
+	StableAfter int      `json:"stableAfter,omitempty"`
+}
+
+// apply returns base overridden by the settings from the file. A zero
+// stableAfter keeps the stableAfter of base.
+func (f *FileAdaptiveIntervalConfig) apply(base AdaptiveIntervalConfig) AdaptiveIntervalConfig {
+	if f == nil {
+		return base
+	}
+	result := AdaptiveIntervalConfig{
+		Degraded:    time.Duration(f.Degraded),
+		Stable:      time.Duration(f.Stable),
+		StableAfter: base.StableAfter,
+	}
+	if f.StableAfter > 0 {
+		result.StableAfter = f.StableAfter
+	}
+	return result
+}
+
+// FileFlapDetectionConfig represents flap detection settings in the JSON file.
+type FileFlapDetectionConfig struct {
+	Window    Duration `json:"window,omitempty"`
+	Threshold int      `json:"threshold,omitempty"`
+	Unhealthy bool     `json:"unhealthy,omitempty"`
+}
+
+// apply returns base overridden by the settings from the file. A zero window
+// keeps the window of base.
+func (f *FileFlapDetectionConfig) apply(base FlapDetectionConfig) FlapDetectionConfig {
+	if f == nil {
+		return base
+	}
+	result := FlapDetectionConfig{
+		Window:    base.Window,
+		Threshold: f.Threshold,
+		Unhealthy: f.Unhealthy,
+	}
+	if f.Window > 0 {
+		result.Window = time.Duration(f.Window)
+	}
+	return result
+}
+
+// FileLatencyConfig represents a mount's latency thresholds in the JSON file.
+type FileLatencyConfig struct {
+	Warn      Duration `json:"warn,omitempty"`
+	Fail      Duration `json:"fail,omitempty"`
+	FailAfter int      `json:"failAfter,omitempty"`
+}
+
+// latencyFromFile converts latency thresholds from the JSON file.
+func latencyFromFile(f *FileLatencyConfig) LatencyConfig {
+	if f == nil {
+		return LatencyConfig{}
+	}
+	return LatencyConfig{Warn: time.Duration(f.Warn), Fail: time.Duration(f.Fail), FailAfter: f.FailAfter}
+}
+
+// FileFailureRule represents a per error class failure rule in the JSON file.
+type FileFailureRule struct {
+	Threshold    int  `json:"threshold,omitempty"`    // 0 = use the mount's failureThreshold, 1 = unhealthy immediately
+	SkipWatchdog bool `json:"skipWatchdog,omitempty"` // Alert only: never trigger the watchdog
+}
+
+// failureRulesFromFile converts failure rules from the JSON file.
+func failureRulesFromFile(rules map[string]FileFailureRule) map[string]FailureRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	converted := make(map[string]FailureRule, len(rules))
+	for class, rule := range rules {
+		converted[class] = FailureRule{
+			Threshold:    rule.Threshold,
+			SkipWatchdog: rule.SkipWatchdog,
+		}
+	}
+	return converted
+}
+
+// FileCheckConfig represents the settings of one check in the JSON file, either
+// a mount's single check or one check of a composite mount check. Only settings
+// common to all check types are decoded here; the check type's provider decodes
+// its own settings from Options.
+type FileCheckConfig struct {
+	CheckType         string   `json:"checkType,omitempty"`
+	CanaryFile        string   `json:"canaryFile,omitempty"`
+	RequireMountpoint bool     `json:"requireMountpoint,omitempty"`
+	FSTypes           []string `json:"fsTypes,omitempty"`
+
+	// Options holds the whole JSON object of the check, including type-specific
+	// settings such as expectedSha256, command or rcUrl
+	Options json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler for FileCheckConfig.
+func (f *FileCheckConfig) UnmarshalJSON(b []byte) error {
+	type fileCheckConfig FileCheckConfig // Without this method, so decoding does not recurse
+	if err := json.Unmarshal(b, (*fileCheckConfig)(f)); err != nil {
+		return err
+	}
+	f.Options = append(json.RawMessage(nil), b...)
+	return nil
 }
 
 // defaultConfigPath is the default location to check for a config file.
@@ -161,17 +318,58 @@ func validateFileConfig(fc *FileConfig) error {
 			}
 			return fmt.Errorf("mount[%d]: failureThreshold must be >= 0, got %d", i, m.FailureThreshold)
 		}
-		if m.CheckType != "" && m.CheckType != "canary" && m.CheckType != "directory" {
+		if err := validateFailureRules(failureRulesFromFile(m.FailureRules)); err != nil {
+			return fmt.Errorf("%s: %w", mountLabel(i, m.Name), err)
+		}
+		if m.RecoveryThreshold < 0 {
+			return fmt.Errorf("%s: recoveryThreshold must be >= 0, got %d", mountLabel(i, m.Name), m.RecoveryThreshold)
+		}
+		if m.FlapDetection != nil && (m.FlapDetection.Threshold < 0 || m.FlapDetection.Window < 0) {
+			return fmt.Errorf("%s: flapDetection threshold and window must be >= 0", mountLabel(i, m.Name))
+		}
+		if m.Check.CheckType != "" && !isValidCheckType(m.Check.CheckType) {
 			if m.Name != "" {
-				return fmt.Errorf("mount[%d] %q: checkType must be one of: canary, directory, got %q", i, m.Name, m.CheckType)
+				return fmt.Errorf("mount[%d] %q: checkType must be one of: %s, got %q", i, m.Name, checkTypeNames(), m.Check.CheckType)
+			}
+			return fmt.Errorf("mount[%d]: checkType must be one of: %s, got %q", i, checkTypeNames(), m.Check.CheckType)
+		}
+		if m.Check.CheckType != "" {
+			if err := validateCheckSettings(m.Path, checkConfigFromFile(m.Check, "")); err != nil {
+				return fmt.Errorf("%s: %w", mountLabel(i, m.Name), err)
+			}
+		}
+		if err := validateMountpoint(m.Check.CheckType, m.Check.RequireMountpoint, m.Check.FSTypes); err != nil {
+			return fmt.Errorf("%s: %w", mountLabel(i, m.Name), err)
+		}
+		if err := validateComposite(m.Check.CheckType, m.CheckMode, m.Check.RequireMountpoint, m.Check.FSTypes, len(m.Checks)); err != nil {
+			return fmt.Errorf("%s: %w", mountLabel(i, m.Name), err)
+		}
+		for j, fc := range m.Checks {
+			if err := validateCheck(m.Path, checkConfigFromFile(fc, "")); err != nil {
+				return fmt.Errorf("%s checks[%d]: %w", mountLabel(i, m.Name), j, err)
 			}
-			return fmt.Errorf("mount[%d]: checkType must be one of: canary, directory, got %q", i, m.CheckType)
 		}
 	}
 
 	return nil
 }
 
+// checkConfigFromFile converts a composite check from the JSON file, falling back
+// to defaultCanary if the check does not set its own canary file.
+func checkConfigFromFile(fc FileCheckConfig, defaultCanary string) CheckConfig {
+	canaryFile := fc.CanaryFile
+	if canaryFile == "" {
+		canaryFile = defaultCanary
+	}
+	return CheckConfig{
+		CheckType:         fc.CheckType,
+		CanaryFile:        canaryFile,
+		RequireMountpoint: fc.RequireMountpoint,
+		FSTypes:           fc.FSTypes,
+		Options:           fc.Options,
+	}
+}
+
 // applyFileConfig applies values from FileConfig to the runtime Config.
 // Values from the file override defaults but will be overridden by CLI flags.
 func applyFileConfig(c *Config, fc *FileConfig) {
@@ -188,9 +386,24 @@ func applyFileConfig(c *Config, fc *FileConfig) {
 	if fc.FailureThreshold > 0 {
 		c.FailureThreshold = fc.FailureThreshold
 	}
+	if fc.RecoveryThreshold > 0 {
+		c.RecoveryThreshold = fc.RecoveryThreshold
+	}
+	c.FailureWindow = fc.FailureWindow.apply(c.FailureWindow)
+	c.FlapDetection = fc.FlapDetection.apply(c.FlapDetection)
+	if fc.MaxConcurrentChecks != nil {
+		c.MaxConcurrentChecks = *fc.MaxConcurrentChecks
+	}
+	if fc.IsolateProbes {
+		c.IsolateProbes = true
+	}
+	c.AdaptiveInterval = fc.AdaptiveInterval.apply(c.AdaptiveInterval)
 	if fc.HTTPPort > 0 {
 		c.HTTPPort = fc.HTTPPort
 	}
+	if fc.APITokensFile != "" {
+		c.APITokensFile = fc.APITokensFile
+	}
 	if fc.LogLevel != "" {
 		c.LogLevel = fc.LogLevel
 	}
@@ -208,27 +421,55 @@ func applyFileConfig(c *Config, fc *FileConfig) {
 		for i, fm := range fc.Mounts {
 			// Apply per-mount config with inheritance from globals
 			mc := MountConfig{
-				Name:      fm.Name,
-				Path:      fm.Path,
-				CheckType: fm.CheckType,
+				Name:              fm.Name,
+				Path:              fm.Path,
+				CheckType:         fm.Check.CheckType,
+				RequireMountpoint: fm.Check.RequireMountpoint,
+				FSTypes:           fm.Check.FSTypes,
+				Options:           fm.Check.Options,
+				CheckMode:         fm.CheckMode,
+				RCRemote:          fm.RCRemote,
+				FailureRules:      failureRulesFromFile(fm.FailureRules),
+				Latency:           latencyFromFile(fm.Latency),
 			}
-			if mc.CheckType == "" {
-				mc.CheckType = "canary"
+			if mc.CheckType == "" && len(fm.Checks) == 0 {
+				mc.CheckType = health.CheckTypeCanary
 			}
 
 			// Inherit canary file from global if not specified
-			if fm.CanaryFile != "" {
-				mc.CanaryFile = fm.CanaryFile
+			if fm.Check.CanaryFile != "" {
+				mc.CanaryFile = fm.Check.CanaryFile
 			} else {
 				mc.CanaryFile = c.CanaryFile
 			}
 
+			// Composite checks inherit the mount's canary file
+			for _, fc := range fm.Checks {
+				mc.Checks = append(mc.Checks, checkConfigFromFile(fc, mc.CanaryFile))
+			}
+
 			// Inherit failure threshold from global if not specified (0 means use default)
 			if fm.FailureThreshold > 0 {
 				mc.FailureThreshold = fm.FailureThreshold
 			} else {
 				mc.FailureThreshold = c.FailureThreshold
 			}
+			if fm.RecoveryThreshold > 0 {
+				mc.RecoveryThreshold = fm.RecoveryThreshold
+			} else {
+				mc.RecoveryThreshold = c.RecoveryThreshold
+			}
+
+			// A mount's failureWindow, flapDetection and adaptiveInterval blocks replace the global settings
+			mc.FailureWindow = fm.FailureWindow.apply(c.FailureWindow)
+			mc.FlapDetection = fm.FlapDetection.apply(c.FlapDetection)
+			mc.AdaptiveInterval = fm.AdaptiveInterval.apply(c.AdaptiveInterval)
+
+			// Probe isolation can be turned on or off per mount
+			mc.IsolateProbes = c.IsolateProbes
+			if fm.IsolateProbes != nil {
+				mc.IsolateProbes = *fm.IsolateProbes
+			}
 
 			c.Mounts[i] = mc
 		}
@@ -251,4 +492,10 @@ func applyFileConfig(c *Config, fc *FileConfig) {
 	if fc.Watchdog.RetryBackoffMax > 0 {
 		c.Watchdog.RetryBackoffMax = time.Duration(fc.Watchdog.RetryBackoffMax)
 	}
+	if len(fc.Watchdog.Remediation) > 0 {
+		c.Watchdog.Remediation = fc.Watchdog.Remediation
+	}
+	if fc.Watchdog.RemediationChecks > 0 {
+		c.Watchdog.RemediationChecks = fc.Watchdog.RemediationChecks
+	}
 }
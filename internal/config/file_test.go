@@ -1,6 +1,9 @@
 package config_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -279,6 +282,394 @@ func TestConfigFile_InvalidCheckType(t *testing.T) {
 	is.True(err != nil) // invalid checkType should error
 }
 
+func TestConfigFile_ContentCheck(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"mounts": [
+			{
+				"path": "/mnt/test",
+				"checkType": "content",
+				"expectedContent": "ok",
+				"minSize": 2
+			}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	content := checkSettings(t, cfg.Mounts[0].SingleCheck()).(health.ContentExpectation)
+	is.Equal(cfg.Mounts[0].CheckType, "content")        // checkType
+	is.Equal(content.Exact, "ok")                       // expectedContent
+	is.Equal(content.MinSize, int64(2))                 // minSize
+	is.Equal(cfg.Mounts[0].CanaryFile, ".health-check") // inherits global canary file
+}
+
+// checkSettings returns the type-specific settings of a check as decoded by its provider.
+func checkSettings(t *testing.T, cc config.CheckConfig) any {
+	t.Helper()
+	spec, err := cc.CheckSpec("/mnt/test")
+	if err != nil {
+		t.Fatalf("failed to decode check settings: %v", err)
+	}
+	return spec.Settings
+}
+
+func TestConfigFile_ContentCheckWithoutExpectation(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"mounts": [
+			{"name": "movies", "path": "/mnt/test", "checkType": "content"}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	err := cfg.LoadFromFileForTesting(configPath)
+
+	is.True(err != nil)                                // content check without expectation should error
+	is.True(strings.Contains(err.Error(), `"movies"`)) // error should identify the mount
+}
+
+func TestConfigFile_WriteCheck(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"mounts": [
+			{"path": "/mnt/test", "checkType": "write", "probeDir": ".probes"},
+			{"path": "/mnt/other", "checkType": "write"}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	is.Equal(cfg.Mounts[0].CheckType, "write")                                                     // checkType
+	is.Equal(checkSettings(t, cfg.Mounts[0].SingleCheck()).(health.WriteSpec).ProbeDir, ".probes") // probeDir
+	is.Equal(checkSettings(t, cfg.Mounts[1].SingleCheck()).(health.WriteSpec).ProbeDir, "")        // probeDir defaults to mount root
+}
+
+func TestConfigFile_WriteCheckAbsoluteProbeDir(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"mounts": [
+			{"path": "/mnt/test", "checkType": "write", "probeDir": "/tmp"}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	err := cfg.LoadFromFileForTesting(configPath)
+
+	is.True(err != nil) // absolute probeDir should error
+}
+
+func TestConfigFile_RangeReadCheck(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"mounts": [
+			{"path": "/mnt/test", "checkType": "range-read", "rangeGlob": "movies/*/*.mkv", "rangeBytes": 131072}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	rangeRead := checkSettings(t, cfg.Mounts[0].SingleCheck()).(health.RangeReadSpec)
+	is.Equal(cfg.Mounts[0].CheckType, "range-read") // checkType
+	is.Equal(rangeRead.Glob, "movies/*/*.mkv")      // rangeGlob
+	is.Equal(rangeRead.Bytes, int64(131072))        // rangeBytes
+}
+
+func TestConfigFile_ListingCheck(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"mounts": [
+			{"path": "/mnt/test", "checkType": "listing", "minEntries": 2, "expectedDirs": ["movies/", "shows/"]}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	listing := checkSettings(t, cfg.Mounts[0].SingleCheck()).(health.ListingSpec)
+	is.Equal(cfg.Mounts[0].CheckType, "listing") // checkType
+	is.Equal(listing.MinEntries, 2)              // minEntries
+	is.Equal(len(listing.ExpectedDirs), 2)       // expectedDirs
+	is.Equal(listing.ExpectedDirs[0], "movies/") // expectedDirs[0]
+}
+
+func TestConfigFile_ExecCheck(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"mounts": [
+			{
+				"path": "/mnt/test",
+				"checkType": "exec",
+				"command": "rclone",
+				"args": ["rc", "vfs/stats"],
+				"env": {"RCLONE_RC_ADDR": "localhost:5572"},
+				"successExitCodes": [0, 3]
+			}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	exec := checkSettings(t, cfg.Mounts[0].SingleCheck()).(health.ExecSpec)
+	is.Equal(cfg.Mounts[0].CheckType, "exec")              // checkType
+	is.Equal(exec.Command, "rclone")                       // command
+	is.Equal(exec.Args[1], "vfs/stats")                    // args
+	is.Equal(exec.Env["RCLONE_RC_ADDR"], "localhost:5572") // env
+	is.Equal(exec.SuccessExitCodes[1], 3)                  // successExitCodes
+}
+
+func TestConfigFile_RcloneRCCheck(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"mounts": [
+			{
+				"path": "/mnt/test",
+				"checkType": "rclone-rc",
+				"rcUrl": "http://rclone:5572",
+				"rcUsername": "rc",
+				"rcPassword": "secret",
+				"rcMountPoint": "/data/debrid",
+				"maxUploadQueue": 20
+			}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	rc := checkSettings(t, cfg.Mounts[0].SingleCheck()).(health.RcloneSpec)
+	is.Equal(cfg.Mounts[0].CheckType, "rclone-rc") // checkType
+	is.Equal(rc.URL, "http://rclone:5572")         // rcUrl
+	is.Equal(rc.Username, "rc")                    // rcUsername
+	is.Equal(rc.Password, "secret")                // rcPassword
+	is.Equal(rc.MountPoint, "/data/debrid")        // rcMountPoint
+	is.Equal(rc.MaxUploadQueue, 20)                // maxUploadQueue
+}
+
+func TestConfigFile_RequireMountpoint(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"mounts": [
+			{"path": "/mnt/test", "requireMountpoint": true, "fsTypes": ["fuse.rclone", "nfs4"]}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	is.Equal(cfg.Mounts[0].CheckType, "canary")       // checkType still defaults to canary
+	is.True(cfg.Mounts[0].RequireMountpoint)          // requireMountpoint
+	is.Equal(cfg.Mounts[0].FSTypes[0], "fuse.rclone") // fsTypes
+}
+
+func TestConfigFile_CompositeChecks(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"canaryFile": ".global-canary",
+		"mounts": [
+			{
+				"path": "/mnt/test",
+				"checkMode": "any",
+				"checks": [
+					{"checkType": "canary"},
+					{"checkType": "listing", "expectedDirs": ["movies"]},
+					{"checkType": "mountinfo", "fsTypes": ["fuse.rclone"]}
+				]
+			}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	m := cfg.Mounts[0]
+	is.Equal(m.CheckType, "")                                                              // composite mounts have no single checkType
+	is.Equal(m.CheckMode, "any")                                                           // checkMode
+	is.Equal(len(m.Checks), 3)                                                             // checks
+	is.Equal(m.Checks[0].CanaryFile, ".global-canary")                                     // checks inherit canary file
+	is.Equal(checkSettings(t, m.Checks[1]).(health.ListingSpec).ExpectedDirs[0], "movies") // listing settings
+	is.Equal(m.Checks[2].FSTypes[0], "fuse.rclone")                                        // mountinfo settings
+}
+
+func TestConfigFile_CompositeChecksInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		mount string
+	}{
+		{"checkType with checks", `{"path": "/mnt/test", "checkType": "canary", "checks": [{"checkType": "directory"}]}`},
+		{"missing sub-check type", `{"path": "/mnt/test", "checks": [{"minEntries": 2}]}`},
+		{"invalid sub-check settings", `{"path": "/mnt/test", "checks": [{"checkType": "content"}]}`},
+		{"invalid check mode", `{"path": "/mnt/test", "checkMode": "some", "checks": [{"checkType": "directory"}]}`},
+		{"check mode without checks", `{"path": "/mnt/test", "checkMode": "any"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			configPath := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(configPath, []byte(`{"mounts": [`+tt.mount+`]}`), 0644); err != nil {
+				t.Fatalf("failed to write config file: %v", err)
+			}
+
+			cfg := config.DefaultConfig()
+			err := cfg.LoadFromFileForTesting(configPath)
+			is.True(err != nil) // invalid composite settings should error
+		})
+	}
+}
+
+// optionsCheck is a custom check type that requires a "target" setting.
+type optionsCheck struct{}
+
+type targetOptions struct {
+	Target string `json:"target"`
+}
+
+func (optionsCheck) Decode(options json.RawMessage) (any, error) {
+	var opts targetOptions
+	if err := json.Unmarshal(options, &opts); err != nil || opts.Target == "" {
+		return nil, errors.New("target is required")
+	}
+	return opts, nil
+}
+
+func (optionsCheck) Run(context.Context, string, *health.CheckSpec) (health.ProbeResult, error) {
+	return health.ProbeResult{}, nil
+}
+
+func TestConfigFile_CustomCheckType(t *testing.T) {
+	is := is.New(t)
+
+	health.RegisterCheckType("test-options", optionsCheck{})
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"mounts": [
+			{"path": "/mnt/test", "checkType": "test-options", "target": "zurg"}
+		]
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	is.NoErr(cfg.LoadFromFileForTesting(configPath))  // registered custom type is accepted
+	is.Equal(cfg.Mounts[0].CheckType, "test-options") // checkType
+
+	spec, err := cfg.Mounts[0].SingleCheck().CheckSpec("/mnt/test")
+	is.NoErr(err)                                          // settings decode
+	is.Equal(spec.Settings, targetOptions{Target: "zurg"}) // provider decoded its own setting
+
+	invalidJSON := `{"mounts": [{"path": "/mnt/test", "checkType": "test-options"}]}`
+	if err := os.WriteFile(configPath, []byte(invalidJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	err = config.DefaultConfig().LoadFromFileForTesting(configPath)
+	is.True(err != nil)                                          // provider validation runs
+	is.True(strings.Contains(err.Error(), "target is required")) // provider error is reported
+}
+
 // T021: Test per-mount failureThreshold override
 func TestConfigFile_PerMountThresholdOverride(t *testing.T) {
 	is := is.New(t)
@@ -317,6 +708,287 @@ func TestConfigFile_PerMountThresholdOverride(t *testing.T) {
 	is.Equal(cfg.Mounts[1].FailureThreshold, 5) // mount[1] inherited threshold
 }
 
+func TestConfigFile_FailureRules(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"mounts": [
+			{
+				"path": "/mnt/test",
+				"failureRules": {
+					"not_connected": {"threshold": 1},
+					"timeout": {"threshold": 5},
+					"permission": {"skipWatchdog": true}
+				}
+			}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	rules := cfg.Mounts[0].FailureRules
+	is.Equal(len(rules), 3)                                               // all rules loaded
+	is.Equal(rules["not_connected"], config.FailureRule{Threshold: 1})    // immediate
+	is.Equal(rules["timeout"], config.FailureRule{Threshold: 5})          // needs 5 in a row
+	is.Equal(rules["permission"], config.FailureRule{SkipWatchdog: true}) // alert only
+}
+
+func TestConfigFile_InvalidFailureRule(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{"mounts": [{"path": "/mnt/test", "failureRules": {"ENOTCONN": {"threshold": 1}}}]}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	err := cfg.LoadFromFileForTesting(configPath)
+	is.True(err != nil)                                    // unknown error class should error
+	is.True(strings.Contains(err.Error(), "failureRules")) // error mentions the setting
+}
+
+func TestConfigFile_RecoveryThreshold(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"recoveryThreshold": 2,
+		"mounts": [
+			{"path": "/mnt/test1", "recoveryThreshold": 5},
+			{"path": "/mnt/test2"}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	is.Equal(cfg.RecoveryThreshold, 1) // default recovers on first pass
+
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	is.Equal(cfg.RecoveryThreshold, 2)           // global recovery threshold
+	is.Equal(cfg.Mounts[0].RecoveryThreshold, 5) // mount[0] custom threshold
+	is.Equal(cfg.Mounts[1].RecoveryThreshold, 2) // mount[1] inherited threshold
+}
+
+func TestConfigFile_MaxConcurrentChecks(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want int
+	}{
+		{"default", `{"mounts": [{"path": "/mnt/test"}]}`, 4},
+		{"explicit", `{"maxConcurrentChecks": 8, "mounts": [{"path": "/mnt/test"}]}`, 8},
+		{"no limit", `{"maxConcurrentChecks": 0, "mounts": [{"path": "/mnt/test"}]}`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			configPath := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(configPath, []byte(tt.json), 0644); err != nil {
+				t.Fatalf("failed to write config file: %v", err)
+			}
+
+			cfg := config.DefaultConfig()
+			if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+				t.Fatalf("failed to load config: %v", err)
+			}
+
+			is.Equal(cfg.MaxConcurrentChecks, tt.want) // max concurrent checks
+		})
+	}
+}
+
+func TestConfigFile_IsolateProbes(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"isolateProbes": true,
+		"mounts": [
+			{"path": "/mnt/test1"},
+			{"path": "/mnt/test2", "isolateProbes": false}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	is.True(!cfg.IsolateProbes) // off by default
+
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	is.True(cfg.IsolateProbes)            // global setting
+	is.True(cfg.Mounts[0].IsolateProbes)  // inherited
+	is.True(!cfg.Mounts[1].IsolateProbes) // turned off for mount[1]
+}
+
+func TestConfigFile_APITokensFile(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"apiTokensFile": "/etc/mount-monitor/tokens",
+		"mounts": [{"path": "/mnt/test1"}]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	is.Equal(cfg.APITokensFile, "") // no tokens by default
+
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	is.Equal(cfg.APITokensFile, "/etc/mount-monitor/tokens") // loaded from file
+}
+
+func TestConfigFile_AdaptiveInterval(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"adaptiveInterval": {"degraded": "10s", "stable": "2m", "stableAfter": 20},
+		"mounts": [
+			{"path": "/mnt/test1", "adaptiveInterval": {"degraded": "6s", "stable": "5m"}},
+			{"path": "/mnt/test2"}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	is.Equal(cfg.AdaptiveInterval, config.AdaptiveIntervalConfig{Degraded: 10 * time.Second, Stable: 2 * time.Minute, StableAfter: 20})          // global settings
+	is.Equal(cfg.Mounts[0].AdaptiveInterval, config.AdaptiveIntervalConfig{Degraded: 6 * time.Second, Stable: 5 * time.Minute, StableAfter: 20}) // mount block, global stableAfter
+	is.Equal(cfg.Mounts[1].AdaptiveInterval, cfg.AdaptiveInterval)                                                                               // inherited
+}
+
+func TestConfigFile_FlapDetection(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"flapDetection": {"window": "30m", "threshold": 6},
+		"mounts": [
+			{"path": "/mnt/test1", "flapDetection": {"threshold": 4, "unhealthy": true}},
+			{"path": "/mnt/test2"}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	is.Equal(cfg.FlapDetection.Threshold, 0) // disabled by default
+
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	is.Equal(cfg.FlapDetection, config.FlapDetectionConfig{Window: 30 * time.Minute, Threshold: 6})                            // global settings
+	is.Equal(cfg.Mounts[0].FlapDetection, config.FlapDetectionConfig{Window: 30 * time.Minute, Threshold: 4, Unhealthy: true}) // mount block, global window
+	is.Equal(cfg.Mounts[1].FlapDetection, cfg.FlapDetection)                                                                   // inherited
+}
+
+func TestConfigFile_FailureWindow(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"failureWindow": {"size": 10, "failures": 6},
+		"mounts": [
+			{"path": "/mnt/test1", "failureWindow": {"size": 5, "failures": 2}},
+			{"path": "/mnt/test2"}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	is.Equal(cfg.FailureWindow, config.FailureWindowConfig{Size: 10, Failures: 6})          // global window
+	is.Equal(cfg.Mounts[0].FailureWindow, config.FailureWindowConfig{Size: 5, Failures: 2}) // mount override
+	is.Equal(cfg.Mounts[1].FailureWindow, cfg.FailureWindow)                                // inherited
+}
+
+func TestConfigFile_Latency(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	configJSON := `{
+		"mounts": [
+			{"path": "/mnt/test1", "latency": {"warn": "2s", "fail": "4500ms", "failAfter": 2}},
+			{"path": "/mnt/test2"}
+		]
+	}`
+
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := cfg.LoadFromFileForTesting(configPath); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	is.Equal(cfg.Mounts[0].Latency, config.LatencyConfig{Warn: 2 * time.Second, Fail: 4500 * time.Millisecond, FailAfter: 2})                 // mount thresholds
+	is.Equal(cfg.Mounts[1].Latency, config.LatencyConfig{})                                                                                   // disabled by default
+	is.Equal(cfg.Mounts[0].Latency.LatencyPolicy(), health.LatencyPolicy{Warn: 2 * time.Second, Fail: 4500 * time.Millisecond, FailAfter: 2}) // converted policy
+}
+
 // T022: Test default inheritance when per-mount values not specified
 func TestConfigFile_DefaultInheritance(t *testing.T) {
 	is := is.New(t)
@@ -597,7 +1269,9 @@ func TestConfigFile_WatchdogConfig(t *testing.T) {
 			"restartDelay": "30s",
 			"maxRetries": 5,
 			"retryBackoffInitial": "200ms",
-			"retryBackoffMax": "20s"
+			"retryBackoffMax": "20s",
+			"remediation": ["vfs-refresh", "remount"],
+			"remediationChecks": 2
 		}
 	}`
 
@@ -610,11 +1284,13 @@ func TestConfigFile_WatchdogConfig(t *testing.T) {
 		t.Fatalf("failed to load config: %v", err)
 	}
 
-	is.Equal(cfg.Watchdog.Enabled, true)                             // watchdog.enabled
-	is.Equal(cfg.Watchdog.RestartDelay, 30*time.Second)              // watchdog.restartDelay
-	is.Equal(cfg.Watchdog.MaxRetries, 5)                             // watchdog.maxRetries
-	is.Equal(cfg.Watchdog.RetryBackoffInitial, 200*time.Millisecond) // watchdog.retryBackoffInitial
-	is.Equal(cfg.Watchdog.RetryBackoffMax, 20*time.Second)           // watchdog.retryBackoffMax
+	is.Equal(cfg.Watchdog.Enabled, true)                                   // watchdog.enabled
+	is.Equal(cfg.Watchdog.RestartDelay, 30*time.Second)                    // watchdog.restartDelay
+	is.Equal(cfg.Watchdog.MaxRetries, 5)                                   // watchdog.maxRetries
+	is.Equal(cfg.Watchdog.RetryBackoffInitial, 200*time.Millisecond)       // watchdog.retryBackoffInitial
+	is.Equal(cfg.Watchdog.RetryBackoffMax, 20*time.Second)                 // watchdog.retryBackoffMax
+	is.Equal(cfg.Watchdog.Remediation, []string{"vfs-refresh", "remount"}) // watchdog.remediation
+	is.Equal(cfg.Watchdog.RemediationChecks, 2)                            // watchdog.remediationChecks
 }
 
 // TestConfigFile_WatchdogEnabled_ExplicitFalse verifies that explicitly setting
@@ -1,12 +1,24 @@
 package health
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
+// ErrContentMismatch indicates the canary file was readable but its content did not
+// match the configured expectation (e.g. a truncated or empty file served from a stale cache).
+var ErrContentMismatch = errors.New("content mismatch")
+
 // Checker performs health checks on mount points.
 type Checker struct {
 	timeout time.Duration
@@ -19,7 +31,8 @@ func NewChecker(timeout time.Duration) *Checker {
 	}
 }
 
-// Check performs a health check on the given mount by reading its canary file.
+// Check performs a health check on the given mount by running its configured check,
+// or, for composite mounts, each of its checks in order under a shared timeout.
 // It returns a CheckResult indicating success or failure.
 func (c *Checker) Check(ctx context.Context, mount *Mount) *CheckResult {
 	start := time.Now()
@@ -29,59 +42,366 @@ func (c *Checker) Check(ctx context.Context, mount *Mount) *CheckResult {
 		Timestamp: start,
 	}
 
+	// Don't pile up blocked goroutines on a hung mount: while a probe of an
+	// earlier check is stuck, fail immediately without starting another one
+	probeID, stuckSince, ok := mount.startProbe(start)
+	if !ok {
+		result.Success = false
+		result.Error = fmt.Errorf("%w for %s", ErrProbeHung, start.Sub(stuckSince).Round(time.Second))
+		result.Reason = ReasonProbeHung
+		return result
+	}
+
 	// Create a timeout context for the check
 	checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
-	// Perform the canary file read in a goroutine to respect context cancellation.
+	specs := mount.checkSpecs()
+	anyMode := mount.IsComposite() && mount.CheckMode == CheckModeAny
+
+	// Perform the checks in a goroutine to respect context cancellation.
 	//
 	// NOTE: os.ReadFile does not respect context cancellation. If the context times out
 	// before ReadFile completes (e.g., on a hung NFS mount), this goroutine will leak
 	// until the underlying I/O operation eventually completes or fails. This is a known
 	// limitation of Go's file I/O - there is no portable way to cancel a blocking read.
-	// In practice, this is acceptable because:
-	// 1. Leaked goroutines will eventually complete when the mount recovers
-	// 2. The memory overhead per goroutine is small (~2KB stack)
-	// 3. Alternative approaches (goroutine pools) add complexity without solving the root cause
-	done := make(chan error, 1)
+	// The mount tracks the goroutine until it returns, and while it is stuck later
+	// checks fail with ErrProbeHung instead of leaking another goroutine each, so
+	// a mount that stays hung for days leaks at most one. Mounts with IsolateProbes
+	// avoid the leak entirely: their checks run in a subprocess that is killed on
+	// timeout.
+	//
+	// Each check's outcome is sent as soon as it completes, so a timeout still reports
+	// the checks that finished before it. The channel is buffered for every check so the
+	// goroutine never blocks after the caller has given up.
+	done := make(chan probeOutcome, len(specs))
+	var helpers *probeRef
+	if mount.IsolateProbes {
+		helpers = &probeRef{mount: mount, id: probeID}
+	}
 	go func() {
-		done <- checkMount(mount)
+		defer close(done)
+		defer mount.finishProbe(probeID) // Before close, so a completed check is never reported stuck
+		if hook := probeReturnHook.Load(); hook != nil {
+			defer (*hook)()
+		}
+		for i := range specs {
+			outcome := runCheck(checkCtx, mount.Path, &specs[i], helpers)
+			done <- outcome
+			// Stop once the combined outcome is decided: the first failure in
+			// "all" mode, the first success in "any" mode.
+			if (outcome.err == nil) == anyMode {
+				return
+			}
+		}
 	}()
 
-	// Wait for either completion or context cancellation
-	select {
-	case err := <-done:
-		result.Duration = time.Since(start)
-		if err != nil {
-			result.Success = false
-			result.Error = err
-		} else {
-			result.Success = true
+	outcomes := make([]probeOutcome, 0, len(specs))
+	var timeoutErr error
+collect:
+	for {
+		select {
+		case outcome, ok := <-done:
+			if !ok {
+				break collect
+			}
+			outcomes = append(outcomes, outcome)
+		case <-checkCtx.Done():
+			outcomes = drainOutcomes(done, outcomes)
+			// The goroutine may still be returning after its last outcome
+			if outcomesDecided(outcomes, len(specs), anyMode) {
+				break collect
+			}
+			timeoutErr = checkCtx.Err()
+			mount.abandonProbe(probeID)
+			break collect
 		}
-	case <-checkCtx.Done():
-		result.Duration = time.Since(start)
+	}
+	result.Duration = time.Since(start)
+
+	var errs []error
+	for _, outcome := range outcomes {
+		result.BytesRead += outcome.bytesRead
+		if outcome.mountInfo != nil && result.MountInfo == nil {
+			result.MountInfo = outcome.mountInfo
+		}
+		if outcome.rclone != nil && result.Rclone == nil {
+			result.Rclone = outcome.rclone
+		}
+		if outcome.err != nil {
+			errs = append(errs, outcome.err)
+		}
+	}
+	if result.BytesRead > 0 && result.Duration > 0 {
+		result.Throughput = float64(result.BytesRead) / result.Duration.Seconds()
+	}
+
+	switch {
+	case timeoutErr != nil:
 		result.Success = false
-		result.Error = checkCtx.Err()
+		result.Error = timeoutErr
+		if mount.IsComposite() {
+			result.Error = fmt.Errorf("%s check: %w", specs[len(outcomes)].CheckType, timeoutErr)
+		}
+	case anyMode && len(errs) < len(outcomes):
+		result.Success = true
+	case len(errs) > 0:
+		result.Success = false
+		result.Error = combineErrors(mount.IsComposite(), outcomes, errs)
+	default:
+		result.Success = true
 	}
 
+	applyLatencyPolicy(result, mount.Latency, mount.countSlowCheck(result))
+	result.Reason = ClassifyFailure(result.Error)
+
+	results := subResults(specs, outcomes, timeoutErr)
+	if mount.IsComposite() {
+		result.SubResults = results
+	}
+	result.Dependencies = dependencyResults(specs, results)
+
 	return result
 }
 
-func checkMount(mount *Mount) error {
-	switch mount.CheckType {
-	case "", CheckTypeCanary:
-		_, err := os.ReadFile(mount.CanaryPath)
-		return err
-	case CheckTypeDirectory:
-		info, err := os.Stat(mount.Path)
+// probeOutcome carries the result of a single check back from the check goroutine.
+type probeOutcome struct {
+	checkType string
+	duration  time.Duration
+	bytesRead int64
+	mountInfo *MountInfo
+	rclone    *RcloneStats
+	err       error
+}
+
+// probeReturnHook, if set, runs in the check goroutine after its last outcome
+// was sent and before it returns. Tests use it to widen that window. It is
+// atomic because check goroutines of earlier tests may still be running when a
+// test changes it.
+var probeReturnHook atomic.Pointer[func()]
+
+// probeWaitHook, if set, runs before a probe helper is waited for. Tests use it
+// to simulate a helper that is never reaped. It is atomic because probes of
+// earlier tests may still be starting helpers when a test changes it.
+var probeWaitHook atomic.Pointer[func()]
+
+// drainOutcomes appends the outcomes already sent on done without waiting for more.
+func drainOutcomes(done <-chan probeOutcome, outcomes []probeOutcome) []probeOutcome {
+	for {
+		select {
+		case outcome, ok := <-done:
+			if !ok {
+				return outcomes
+			}
+			outcomes = append(outcomes, outcome)
+		default:
+			return outcomes
+		}
+	}
+}
+
+// outcomesDecided reports whether outcomes already decide the combined result:
+// every check ran, or the last one was the first failure in "all" mode or the
+// first success in "any" mode, after which no further check is run.
+func outcomesDecided(outcomes []probeOutcome, total int, anyMode bool) bool {
+	if len(outcomes) == total {
+		return true
+	}
+	n := len(outcomes)
+	return n > 0 && (outcomes[n-1].err == nil) == anyMode
+}
+
+// runCheck verifies the mountpoint if required, then runs the check's type
+// against the mount at path. If helpers is set, the check runs in a probe helper
+// subprocess that keeps the referenced probe registered until it is reaped.
+func runCheck(ctx context.Context, path string, spec *CheckSpec, helpers *probeRef) probeOutcome {
+	if helpers != nil {
+		return runIsolated(ctx, path, spec, *helpers)
+	}
+	start := time.Now()
+	outcome := probeOutcome{checkType: spec.CheckType}
+
+	if spec.verifiesMountpoint() {
+		info, err := verifyMountpoint(path, spec.Mountpoint)
+		outcome.mountInfo = info
 		if err != nil {
-			return err
+			outcome.err = err
+			outcome.duration = time.Since(start)
+			return outcome
+		}
+	}
+
+	result, err := runCheckType(ctx, path, spec)
+	outcome.bytesRead = result.BytesRead
+	outcome.rclone = result.Rclone
+	outcome.err = err
+	outcome.duration = time.Since(start)
+	return outcome
+}
+
+// runCheckType runs the check's registered provider against the mount at path.
+func runCheckType(ctx context.Context, path string, spec *CheckSpec) (ProbeResult, error) {
+	checkType := spec.CheckType
+	if checkType == "" {
+		checkType = CheckTypeCanary
+	}
+	provider, ok := LookupCheckType(checkType)
+	if !ok {
+		return ProbeResult{}, fmt.Errorf("unsupported check type %q", spec.CheckType)
+	}
+	return provider.Run(ctx, path, spec)
+}
+
+// canaryCheck reads the canary file.
+type canaryCheck struct{}
+
+func (canaryCheck) Decode(json.RawMessage) (any, error) { return nil, nil }
+
+func (canaryCheck) Run(_ context.Context, _ string, spec *CheckSpec) (ProbeResult, error) {
+	_, err := os.ReadFile(spec.CanaryPath)
+	return ProbeResult{}, err
+}
+
+// directoryCheck verifies the mount path exists and is a directory.
+type directoryCheck struct{}
+
+func (directoryCheck) Decode(json.RawMessage) (any, error) { return nil, nil }
+
+func (directoryCheck) Run(_ context.Context, path string, _ *CheckSpec) (ProbeResult, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+	if !info.IsDir() {
+		return ProbeResult{}, fmt.Errorf("path is not a directory: %s", path)
+	}
+	return ProbeResult{}, nil
+}
+
+// contentCheck reads the canary file and verifies its content.
+type contentCheck struct{}
+
+// Decode requires at least one expectation, otherwise the check is equivalent to "canary".
+func (contentCheck) Decode(options json.RawMessage) (any, error) {
+	want, err := decodeOptions[ContentExpectation](options)
+	if err != nil {
+		return nil, err
+	}
+	if want.SHA256 == "" && want.Exact == "" && want.MinSize == 0 {
+		return nil, fmt.Errorf("content check requires at least one of expectedSha256, expectedContent or minSize")
+	}
+	if want.MinSize < 0 {
+		return nil, fmt.Errorf("minSize must be >= 0, got %d", want.MinSize)
+	}
+	if want.SHA256 != "" {
+		if len(want.SHA256) != 64 {
+			return nil, fmt.Errorf("expectedSha256 must be 64 hex characters, got %d", len(want.SHA256))
 		}
-		if !info.IsDir() {
-			return fmt.Errorf("path is not a directory: %s", mount.Path)
+		if _, err := hex.DecodeString(want.SHA256); err != nil {
+			return nil, fmt.Errorf("expectedSha256 is not valid hex: %w", err)
 		}
-		return nil
-	default:
-		return fmt.Errorf("unsupported check type %q", mount.CheckType)
 	}
+	return want, nil
+}
+
+func (contentCheck) Run(_ context.Context, _ string, spec *CheckSpec) (ProbeResult, error) {
+	data, err := os.ReadFile(spec.CanaryPath)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+	return ProbeResult{}, verifyContent(data, settingsOf[ContentExpectation](spec))
+}
+
+// combineErrors builds the error reported for a failed check. A single-check mount
+// reports its error unchanged; a composite mount prefixes each failed sub-check's
+// error with its check type. The combined error still matches errors.Is for every cause.
+func combineErrors(composite bool, outcomes []probeOutcome, errs []error) error {
+	if !composite {
+		return errs[0]
+	}
+	formats := make([]string, 0, len(errs))
+	args := make([]any, 0, 2*len(errs))
+	for _, outcome := range outcomes {
+		if outcome.err != nil {
+			formats = append(formats, "%s check: %w")
+			args = append(args, outcome.checkType, outcome.err)
+		}
+	}
+	return fmt.Errorf(strings.Join(formats, "; "), args...)
+}
+
+// dependencyResults returns the results of the checks whose provider probes a
+// dependency of the mount, or nil if there are none.
+func dependencyResults(specs []CheckSpec, results []SubCheckResult) []DependencyResult {
+	var deps []DependencyResult
+	for i := range specs {
+		provider, ok := LookupCheckType(specs[i].CheckType)
+		if !ok {
+			continue
+		}
+		dp, ok := provider.(DependencyProvider)
+		if !ok {
+			continue
+		}
+		deps = append(deps, DependencyResult{
+			SubCheckResult: results[i],
+			Target:         dp.Dependency(&specs[i]),
+		})
+	}
+	return deps
+}
+
+// subResults builds the per-check results of a check run, one per spec. Checks that
+// did not complete before the timeout are reported as failed with the timeout error,
+// and checks that were not run because the outcome was already decided as skipped.
+func subResults(specs []CheckSpec, outcomes []probeOutcome, timeoutErr error) []SubCheckResult {
+	results := make([]SubCheckResult, len(specs))
+	for i := range specs {
+		results[i].CheckType = specs[i].CheckType
+		switch {
+		case i < len(outcomes):
+			results[i].Success = outcomes[i].err == nil
+			results[i].Duration = outcomes[i].duration
+			results[i].Error = outcomes[i].err
+		case i == len(outcomes) && timeoutErr != nil:
+			results[i].Error = timeoutErr
+		default:
+			results[i].Skipped = true
+		}
+	}
+	return results
+}
+
+// verifyContent compares canary file data against the expected content.
+// Mismatches are reported as errors wrapping ErrContentMismatch.
+func verifyContent(data []byte, want ContentExpectation) error {
+	if int64(len(data)) < want.MinSize {
+		return fmt.Errorf("%w: size %d bytes is below minimum %d", ErrContentMismatch, len(data), want.MinSize)
+	}
+	if want.Exact != "" {
+		got := bytes.TrimRight(data, "\r\n")
+		if string(got) != strings.TrimRight(want.Exact, "\r\n") {
+			return fmt.Errorf("%w: content does not match expected value", ErrContentMismatch)
+		}
+	}
+	if want.SHA256 != "" {
+		sum := sha256.Sum256(data)
+		got := hex.EncodeToString(sum[:])
+		if !strings.EqualFold(got, want.SHA256) {
+			return fmt.Errorf("%w: sha256 %s does not match expected %s", ErrContentMismatch, got, strings.ToLower(want.SHA256))
+		}
+	}
+	return nil
+}
+
+// validateMountRelativePath checks that a path is relative and does not escape the mount.
+func validateMountRelativePath(field, p string) error {
+	if filepath.IsAbs(p) {
+		return fmt.Errorf("%s must be relative to the mount path, got %q", field, p)
+	}
+	cleaned := filepath.Clean(p)
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%s must not escape the mount path, got %q", field, p)
+	}
+	return nil
 }
@@ -2,8 +2,10 @@ package health_test
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -166,3 +168,411 @@ func TestChecker_DirectoryCheck_FilePath(t *testing.T) {
 	is.True(!result.Success)     // file path should fail directory check
 	is.True(result.Error != nil) // error expected
 }
+
+func TestChecker_ContentCheck(t *testing.T) {
+	// sha256("ok\n")
+	const okSHA256 = "dc51b8c96c2d745df3bd5590d990230a482fd247123599548e0632fdbf97fc22"
+
+	tests := []struct {
+		name     string
+		content  string
+		expect   health.ContentExpectation
+		wantPass bool
+	}{
+		{"exact match", "ok\n", health.ContentExpectation{Exact: "ok"}, true},
+		{"exact mismatch", "stale", health.ContentExpectation{Exact: "ok"}, false},
+		{"min size met", "ok\n", health.ContentExpectation{MinSize: 3}, true},
+		{"empty file below min size", "", health.ContentExpectation{MinSize: 1}, false},
+		{"sha256 match", "ok\n", health.ContentExpectation{SHA256: okSHA256}, true},
+		{"sha256 match uppercase", "ok\n", health.ContentExpectation{SHA256: strings.ToUpper(okSHA256)}, true},
+		{"sha256 mismatch", "ok", health.ContentExpectation{SHA256: okSHA256}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, ".health-check"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create canary file: %v", err)
+			}
+
+			mount := health.NewMountWithCheckType("", tmpDir, ".health-check", health.CheckTypeContent, 3)
+			mount.Settings = tt.expect
+			checker := health.NewChecker(5 * time.Second)
+
+			result := checker.Check(context.Background(), mount)
+
+			is.Equal(result.Success, tt.wantPass) // check outcome
+			if !tt.wantPass {
+				is.True(errors.Is(result.Error, health.ErrContentMismatch)) // failure should be a content mismatch
+			}
+		})
+	}
+}
+
+func TestChecker_ContentCheck_MissingFile(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("", t.TempDir(), ".health-check", health.CheckTypeContent, 3)
+	mount.Settings = health.ContentExpectation{MinSize: 1}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                                     // missing canary should fail
+	is.True(!errors.Is(result.Error, health.ErrContentMismatch)) // read errors are not content mismatches
+}
+
+func TestChecker_WriteCheck(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	mount := health.NewMountWithCheckType("", tmpDir, "", health.CheckTypeWrite, 3)
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(result.Success) // writable mount should pass
+	is.NoErr(result.Error)  // no error expected
+
+	entries, err := os.ReadDir(tmpDir)
+	is.NoErr(err)
+	is.Equal(len(entries), 0) // probe file should be removed
+}
+
+func TestChecker_WriteCheck_ProbeDir(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	probeDir := filepath.Join(tmpDir, ".probes")
+	is.NoErr(os.Mkdir(probeDir, 0755))
+
+	// A stale probe left behind by a crashed run, and a recent one from another replica
+	stale := filepath.Join(probeDir, ".mount-monitor-probe-stale")
+	recent := filepath.Join(probeDir, ".mount-monitor-probe-recent")
+	unrelated := filepath.Join(probeDir, "keep-me")
+	for _, p := range []string{stale, recent, unrelated} {
+		is.NoErr(os.WriteFile(p, []byte("x"), 0644))
+	}
+	old := time.Now().Add(-time.Hour)
+	is.NoErr(os.Chtimes(stale, old, old))
+	is.NoErr(os.Chtimes(unrelated, old, old))
+
+	mount := health.NewMountWithCheckType("", tmpDir, "", health.CheckTypeWrite, 3)
+	mount.Settings = health.WriteSpec{ProbeDir: ".probes"}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+	is.True(result.Success) // writable probe dir should pass
+
+	_, err := os.Stat(stale)
+	is.True(errors.Is(err, os.ErrNotExist)) // orphaned probe should be cleaned up
+	_, err = os.Stat(recent)
+	is.NoErr(err) // recent probe should be left alone
+	_, err = os.Stat(unrelated)
+	is.NoErr(err) // non-probe files should never be removed
+}
+
+func TestChecker_WriteCheck_ReadOnly(t *testing.T) {
+	is := is.New(t)
+
+	if os.Getuid() == 0 {
+		t.Skip("skipping permission test when running as root")
+	}
+
+	tmpDir := t.TempDir()
+	is.NoErr(os.Chmod(tmpDir, 0555))
+	defer os.Chmod(tmpDir, 0755) // Cleanup
+
+	mount := health.NewMountWithCheckType("", tmpDir, "", health.CheckTypeWrite, 3)
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)     // read-only mount should fail write check
+	is.True(result.Error != nil) // error expected
+}
+
+func TestChecker_WriteCheck_MissingProbeDir(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", health.CheckTypeWrite, 3)
+	mount.Settings = health.WriteSpec{ProbeDir: "does-not-exist"}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                         // missing probe dir should fail
+	is.True(errors.Is(result.Error, os.ErrNotExist)) // error should wrap not-exist
+}
+
+func TestChecker_RangeReadCheck_File(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	data := make([]byte, 256<<10)
+	is.NoErr(os.WriteFile(filepath.Join(tmpDir, "movie.mkv"), data, 0644))
+
+	mount := health.NewMountWithCheckType("", tmpDir, "", health.CheckTypeRangeRead, 3)
+	mount.Settings = health.RangeReadSpec{File: "movie.mkv", Bytes: 4096}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.NoErr(result.Error)                  // no error expected
+	is.True(result.Success)                 // range read should pass
+	is.Equal(result.BytesRead, int64(4096)) // configured byte count read
+	is.True(result.Throughput > 0)          // throughput reported
+}
+
+func TestChecker_RangeReadCheck_DefaultBytesSmallFile(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(tmpDir, "small.bin"), []byte("0123456789"), 0644))
+
+	mount := health.NewMountWithCheckType("", tmpDir, "", health.CheckTypeRangeRead, 3)
+	mount.Settings = health.RangeReadSpec{File: "small.bin"}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(result.Success)               // range read should pass
+	is.Equal(result.BytesRead, int64(10)) // read is capped at file size
+}
+
+func TestChecker_RangeReadCheck_Glob(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	is.NoErr(os.Mkdir(filepath.Join(tmpDir, "movies"), 0755))
+	is.NoErr(os.Mkdir(filepath.Join(tmpDir, "movies", "dir.mkv"), 0755))                       // directories are skipped
+	is.NoErr(os.WriteFile(filepath.Join(tmpDir, "movies", "empty.mkv"), nil, 0644))            // empty files are skipped
+	is.NoErr(os.WriteFile(filepath.Join(tmpDir, "movies", "a.mkv"), make([]byte, 1024), 0644)) // the only candidate
+
+	mount := health.NewMountWithCheckType("", tmpDir, "", health.CheckTypeRangeRead, 3)
+	mount.Settings = health.RangeReadSpec{Glob: "movies/*.mkv", Bytes: 512}
+	checker := health.NewChecker(5 * time.Second)
+
+	for i := 0; i < 5; i++ {
+		result := checker.Check(context.Background(), mount)
+		is.NoErr(result.Error)                 // candidate file should be selected
+		is.Equal(result.BytesRead, int64(512)) // configured byte count read
+	}
+}
+
+func TestChecker_RangeReadCheck_NoGlobMatch(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", health.CheckTypeRangeRead, 3)
+	mount.Settings = health.RangeReadSpec{Glob: "*.mkv"}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                                         // empty library should fail
+	is.True(errors.Is(result.Error, health.ErrNoRangeReadCandidate)) // distinct error for no candidates
+}
+
+func TestChecker_RangeReadCheck_MissingFile(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", health.CheckTypeRangeRead, 3)
+	mount.Settings = health.RangeReadSpec{File: "missing.mkv"}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                         // missing file should fail
+	is.True(errors.Is(result.Error, os.ErrNotExist)) // not-exist error
+	is.Equal(result.BytesRead, int64(0))             // nothing read
+}
+
+func TestChecker_ListingCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		dirs     []string
+		files    []string
+		spec     health.ListingSpec
+		wantPass bool
+	}{
+		{"empty mount fails by default", nil, nil, health.ListingSpec{}, false},
+		{"non-empty mount passes by default", []string{"movies"}, nil, health.ListingSpec{}, true},
+		{"below minimum", []string{"movies"}, []string{"a"}, health.ListingSpec{MinEntries: 3}, false},
+		{"minimum met", []string{"movies"}, []string{"a", "b"}, health.ListingSpec{MinEntries: 3}, true},
+		{"expected dirs present", []string{"movies", "shows"}, nil, health.ListingSpec{ExpectedDirs: []string{"movies/", "shows"}}, true},
+		{"expected dir missing", []string{"movies"}, nil, health.ListingSpec{ExpectedDirs: []string{"movies", "shows"}}, false},
+		{"expected dir is a file", []string{"movies"}, []string{"shows"}, health.ListingSpec{ExpectedDirs: []string{"shows/"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			tmpDir := t.TempDir()
+			for _, d := range tt.dirs {
+				is.NoErr(os.Mkdir(filepath.Join(tmpDir, d), 0755))
+			}
+			for _, f := range tt.files {
+				is.NoErr(os.WriteFile(filepath.Join(tmpDir, f), []byte("x"), 0644))
+			}
+
+			mount := health.NewMountWithCheckType("", tmpDir, "", health.CheckTypeListing, 3)
+			mount.Settings = tt.spec
+			checker := health.NewChecker(5 * time.Second)
+
+			result := checker.Check(context.Background(), mount)
+
+			is.Equal(result.Success, tt.wantPass) // check outcome
+			if !tt.wantPass {
+				is.True(errors.Is(result.Error, health.ErrListingMismatch)) // failure should be a listing mismatch
+			}
+		})
+	}
+}
+
+func TestChecker_ListingCheck_MissingPath(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("", "/nonexistent/path/that/does/not/exist", "", health.CheckTypeListing, 3)
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                         // missing path should fail
+	is.True(errors.Is(result.Error, os.ErrNotExist)) // not-exist rather than listing mismatch
+}
+
+func TestChecker_CompositeAll(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(tmpDir, ".health-check"), []byte("ok"), 0644))
+
+	mount := health.NewMount("", tmpDir, ".health-check", 3)
+	mount.Checks = []health.CheckSpec{
+		health.NewCheckSpec(tmpDir, ".health-check", health.CheckTypeCanary),
+		health.NewCheckSpec(tmpDir, "", health.CheckTypeDirectory),
+	}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(result.Success)             // all checks pass
+	is.NoErr(result.Error)              // no error expected
+	is.Equal(len(result.SubResults), 2) // one result per check
+	for _, sub := range result.SubResults {
+		is.Equal(sub.Status(), "passed") // every check passed
+	}
+}
+
+func TestChecker_CompositeAll_StopsAtFirstFailure(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+
+	mount := health.NewMount("", tmpDir, ".health-check", 3)
+	mount.Checks = []health.CheckSpec{
+		health.NewCheckSpec(tmpDir, "", health.CheckTypeDirectory),
+		health.NewCheckSpec(tmpDir, "", health.CheckTypeListing),
+		health.NewCheckSpec(tmpDir, ".health-check", health.CheckTypeCanary),
+	}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                                    // empty mount fails the listing check
+	is.True(errors.Is(result.Error, health.ErrListingMismatch)) // cause is preserved
+	is.True(strings.Contains(result.Error.Error(), "listing"))  // error names the failing check
+	is.Equal(result.SubResults[0].Status(), "passed")           // directory check ran
+	is.Equal(result.SubResults[1].Status(), "failed")           // listing check failed
+	is.Equal(result.SubResults[2].Status(), "skipped")          // canary check was not run
+}
+
+func TestChecker_CompositeAny(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+
+	mount := health.NewMount("", tmpDir, ".health-check", 3)
+	mount.CheckMode = health.CheckModeAny
+	mount.Checks = []health.CheckSpec{
+		health.NewCheckSpec(tmpDir, ".health-check", health.CheckTypeCanary),
+		health.NewCheckSpec(tmpDir, "", health.CheckTypeDirectory),
+		health.NewCheckSpec(tmpDir, "", health.CheckTypeListing),
+	}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(result.Success)                            // directory check passes
+	is.NoErr(result.Error)                             // no error when any check passes
+	is.Equal(result.SubResults[0].Status(), "failed")  // canary file is missing
+	is.Equal(result.SubResults[1].Status(), "passed")  // directory check passed
+	is.Equal(result.SubResults[2].Status(), "skipped") // listing check was not needed
+}
+
+// TestChecker_TimeoutAfterLastOutcome tests that a timeout expiring after the
+// last outcome was sent, while the check goroutine is still returning, does not
+// turn a decided result into a timeout.
+func TestChecker_TimeoutAfterLastOutcome(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		statuses []string
+	}{
+		{"all checks ran", health.CheckModeAll, []string{"passed", "passed"}},
+		{"decided early", health.CheckModeAny, []string{"passed", "skipped"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			// Hold the check goroutine after its last outcome until the timeout expired
+			release := make(chan struct{})
+			restore := health.SetProbeReturnHookForTesting(func() { <-release })
+			defer restore()
+			defer close(release)
+
+			tmpDir := t.TempDir()
+			mount := health.NewMount("", tmpDir, ".health-check", 3)
+			mount.CheckMode = tt.mode
+			mount.Checks = []health.CheckSpec{
+				health.NewCheckSpec(tmpDir, "", health.CheckTypeDirectory),
+				health.NewCheckSpec(tmpDir, "", health.CheckTypeDirectory),
+			}
+			checker := health.NewChecker(20 * time.Millisecond)
+
+			result := checker.Check(context.Background(), mount)
+
+			is.True(result.Success) // decided before the timeout
+			is.NoErr(result.Error)  // not reported as a timeout
+			for i, status := range tt.statuses {
+				is.Equal(result.SubResults[i].Status(), status) // sub-results unaffected by the timeout
+			}
+			is.Equal(mount.Snapshot().StuckProbes, 0) // returning goroutine is not stuck
+		})
+	}
+}
+
+func TestChecker_CompositeAny_AllFail(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+
+	mount := health.NewMount("", tmpDir, ".health-check", 3)
+	mount.CheckMode = health.CheckModeAny
+	mount.Checks = []health.CheckSpec{
+		health.NewCheckSpec(tmpDir, ".health-check", health.CheckTypeCanary),
+		health.NewCheckSpec(tmpDir, "", health.CheckTypeListing),
+	}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                                    // no check passes
+	is.True(errors.Is(result.Error, os.ErrNotExist))            // canary error is reported
+	is.True(errors.Is(result.Error, health.ErrListingMismatch)) // listing error is reported
+}
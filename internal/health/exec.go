@@ -0,0 +1,170 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrExecFailed indicates the exec check's command ran but exited with a code
+// not listed as successful.
+var ErrExecFailed = errors.New("exec check failed")
+
+const (
+	// execOutputLimit is the maximum number of bytes of stdout and of stderr kept
+	// for the error message. Probe scripts should be terse; anything longer is noise
+	// in logs and /healthz/status.
+	execOutputLimit = 512
+
+	// execWaitDelay bounds how long to wait for output pipes to close after the
+	// process group was killed, in case a descendant escaped the group.
+	execWaitDelay = time.Second
+
+	// execMountPathEnv is set in the command's environment to the mount path.
+	execMountPathEnv = "MOUNT_PATH"
+)
+
+// ExecSpec describes the command an exec check runs.
+type ExecSpec struct {
+	Command          string            `json:"command"`          // Executable to run (absolute path or looked up in PATH)
+	Args             []string          `json:"args"`             // Command arguments
+	Env              map[string]string `json:"env"`              // Extra environment variables, added to the monitor's environment
+	SuccessExitCodes []int             `json:"successExitCodes"` // Exit codes treated as healthy (empty = only 0)
+}
+
+// execCheck runs an external command as the health probe.
+type execCheck struct{}
+
+func (execCheck) Decode(options json.RawMessage) (any, error) {
+	e, err := decodeOptions[ExecSpec](options)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(e.Command) == "" {
+		return nil, fmt.Errorf("exec check requires command")
+	}
+	for k := range e.Env {
+		if k == "" || strings.ContainsAny(k, "=\x00") {
+			return nil, fmt.Errorf("env keys must be non-empty and must not contain '=', got %q", k)
+		}
+	}
+	for _, code := range e.SuccessExitCodes {
+		if code < 0 || code > 255 {
+			return nil, fmt.Errorf("successExitCodes must be between 0 and 255, got %d", code)
+		}
+	}
+	return e, nil
+}
+
+// Run starts the command in its own process group and waits for it to exit.
+// When ctx is done the whole process group is killed, so no processes outlive the check.
+func (execCheck) Run(ctx context.Context, mountPath string, spec *CheckSpec) (ProbeResult, error) {
+	e := settingsOf[ExecSpec](spec)
+	cmd := exec.CommandContext(ctx, e.Command, e.Args...)
+	cmd.Env = execEnv(mountPath, e.Env)
+
+	stdout := &limitedBuffer{limit: execOutputLimit}
+	stderr := &limitedBuffer{limit: execOutputLimit}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	setProcessGroup(cmd)
+	cmd.Cancel = func() error { return killProcessGroup(cmd) }
+	cmd.WaitDelay = execWaitDelay
+
+	err := cmd.Run()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ProbeResult{}, fmt.Errorf("command %s: %w", e.Command, ctxErr)
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		// The command could not be started (e.g. not found or not executable)
+		return ProbeResult{}, err
+	}
+
+	code := cmd.ProcessState.ExitCode()
+	if isSuccessExitCode(code, e.SuccessExitCodes) {
+		return ProbeResult{}, nil
+	}
+	return ProbeResult{}, fmt.Errorf("%w: %s exited with code %d%s", ErrExecFailed, e.Command, code, formatExecOutput(stdout, stderr))
+}
+
+// execEnv returns the command's environment: the monitor's own environment, the
+// configured extra variables, and MOUNT_PATH. Keys are sorted for deterministic order.
+func execEnv(mountPath string, extra map[string]string) []string {
+	env := os.Environ()
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		env = append(env, k+"="+extra[k])
+	}
+	return append(env, execMountPathEnv+"="+mountPath)
+}
+
+// isSuccessExitCode reports whether code counts as a successful probe.
+func isSuccessExitCode(code int, successCodes []int) bool {
+	if len(successCodes) == 0 {
+		return code == 0
+	}
+	for _, c := range successCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// formatExecOutput renders captured output for inclusion in an error message.
+func formatExecOutput(stdout, stderr *limitedBuffer) string {
+	var b strings.Builder
+	if s := stderr.String(); s != "" {
+		fmt.Fprintf(&b, ": stderr: %s", s)
+	}
+	if s := stdout.String(); s != "" {
+		fmt.Fprintf(&b, ": stdout: %s", s)
+	}
+	return b.String()
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the rest.
+// Writes never fail, so a chatty command is not killed by a broken pipe.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room > 0 {
+		if len(p) > room {
+			b.buf.Write(p[:room])
+			b.truncated = true
+		} else {
+			b.buf.Write(p)
+		}
+	} else if len(p) > 0 {
+		b.truncated = true
+	}
+	return len(p), nil
+}
+
+// String returns the captured output with surrounding whitespace trimmed,
+// marked with "..." if it was truncated.
+func (b *limitedBuffer) String() string {
+	s := strings.TrimSpace(b.buf.String())
+	if b.truncated {
+		s += "..."
+	}
+	return s
+}
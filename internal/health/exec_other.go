@@ -0,0 +1,17 @@
+//go:build !unix
+
+package health
+
+import "os/exec"
+
+// setProcessGroup is a no-op on platforms without Unix process groups.
+func setProcessGroup(*exec.Cmd) {}
+
+// killProcessGroup kills the command's process. Children it spawned are not
+// killed on platforms without Unix process groups.
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,174 @@
+//go:build unix
+
+package health_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/cscheib/debrid-mount-monitor/internal/testutil"
+	"github.com/matryer/is"
+)
+
+func newExecMount(t *testing.T, spec health.ExecSpec) *health.Mount {
+	t.Helper()
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", health.CheckTypeExec, 3)
+	mount.Settings = spec
+	return mount
+}
+
+// shellExec returns the settings of an exec check that runs script with /bin/sh.
+func shellExec(script string) health.ExecSpec {
+	return health.ExecSpec{Command: "/bin/sh", Args: []string{"-c", script}}
+}
+
+func TestChecker_ExecCheck(t *testing.T) {
+	tests := []struct {
+		name         string
+		script       string
+		successCodes []int
+		wantPass     bool
+	}{
+		{"exit 0 passes", "exit 0", nil, true},
+		{"exit 1 fails", "exit 1", nil, false},
+		{"configured success code", "exit 3", []int{0, 3}, true},
+		{"exit 0 not in configured codes", "exit 0", []int{3}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			spec := shellExec(tt.script)
+			spec.SuccessExitCodes = tt.successCodes
+			mount := newExecMount(t, spec)
+			checker := health.NewChecker(5 * time.Second)
+
+			result := checker.Check(context.Background(), mount)
+
+			is.Equal(result.Success, tt.wantPass) // exit code decides the outcome
+			if !tt.wantPass {
+				is.True(errors.Is(result.Error, health.ErrExecFailed)) // failure should be an exec failure
+			}
+		})
+	}
+}
+
+func TestChecker_ExecCheck_CapturesOutput(t *testing.T) {
+	is := is.New(t)
+
+	mount := newExecMount(t, shellExec(`echo "vfs queue stuck" >&2; head -c 4096 /dev/zero | tr '\0' x; exit 2`))
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success) // non-zero exit should fail
+	msg := result.Error.Error()
+	is.True(strings.Contains(msg, "exited with code 2"))      // exit code is reported
+	is.True(strings.Contains(msg, "stderr: vfs queue stuck")) // stderr is reported
+	is.True(strings.Contains(msg, "..."))                     // long stdout is truncated
+	is.True(len(msg) < 2048)                                  // output is bounded
+}
+
+func TestChecker_ExecCheck_Environment(t *testing.T) {
+	is := is.New(t)
+
+	spec := shellExec(`test "$PROBE_TARGET" = zurg && test "$MOUNT_PATH" = "$EXPECTED_PATH"`)
+	mount := newExecMount(t, spec)
+	spec.Env = map[string]string{"PROBE_TARGET": "zurg", "EXPECTED_PATH": mount.Path}
+	mount.Settings = spec
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.NoErr(result.Error) // configured env and MOUNT_PATH should be set
+}
+
+func TestChecker_ExecCheck_CommandNotFound(t *testing.T) {
+	is := is.New(t)
+
+	mount := newExecMount(t, health.ExecSpec{Command: "/nonexistent/probe"})
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                         // missing command should fail
+	is.True(errors.Is(result.Error, os.ErrNotExist)) // not-exist error
+}
+
+// TestChecker_ExecCheck_TimeoutKillsProcessGroup verifies that a command and its
+// children are killed when the read timeout expires.
+func TestChecker_ExecCheck_TimeoutKillsProcessGroup(t *testing.T) {
+	is := is.New(t)
+
+	pidFile := filepath.Join(t.TempDir(), "child.pid")
+	spec := shellExec(`sleep 30 & echo $! > "$PID_FILE"; wait`)
+	spec.Env = map[string]string{"PID_FILE": pidFile}
+	mount := newExecMount(t, spec)
+	checker := health.NewChecker(300 * time.Millisecond)
+
+	start := time.Now()
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                                   // timed out command should fail
+	is.True(errors.Is(result.Error, context.DeadlineExceeded)) // timeout error
+	is.True(time.Since(start) < 5*time.Second)                 // check returns promptly
+
+	data, err := os.ReadFile(pidFile)
+	is.NoErr(err) // child should have recorded its pid
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	is.NoErr(err) // pid should be numeric
+
+	// The child is killed asynchronously once the check's context is cancelled
+	testutil.PollUntil(t, 5*time.Second, func() bool {
+		return !processAlive(pid)
+	})
+}
+
+// processAlive reports whether a process exists and is not a zombie. Killed
+// children are reparented to init, which may not reap them promptly in a container.
+func processAlive(pid int) bool {
+	if syscall.Kill(pid, 0) != nil {
+		return false
+	}
+	stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return true
+	}
+	// The state follows the parenthesised command name: "pid (comm) S ..."
+	fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+	return len(fields) == 0 || fields[0] != "Z"
+}
+
+func TestExecCheck_Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    health.ExecSpec
+		wantErr bool
+	}{
+		{"command", health.ExecSpec{Command: "/usr/local/bin/probe"}, false},
+		{"missing command", health.ExecSpec{}, true},
+		{"invalid env key", health.ExecSpec{Command: "probe", Env: map[string]string{"A=B": "c"}}, true},
+		{"invalid exit code", health.ExecSpec{Command: "probe", SuccessExitCodes: []int{256}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			spec := health.NewCheckSpec("/mnt/test", "", health.CheckTypeExec)
+			spec.Options = checkOptions(t, tt.spec)
+
+			err := health.DecodeCheckSpec(&spec)
+			is.Equal(err != nil, tt.wantErr) // validation outcome
+		})
+	}
+}
@@ -0,0 +1,23 @@
+//go:build unix
+
+package health
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts the command in a new process group, so the command
+// and any children it spawns can be killed together.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the command's whole process group.
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	// A negative pid signals every process in the group whose ID is -pid
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,69 @@
+package health
+
+import "time"
+
+// FlapPolicy configures flap detection for a mount. A mount is flapping when it
+// changes state at least Threshold times within Window, and stops flapping once
+// fewer than half as many transitions remain in the window.
+type FlapPolicy struct {
+	Window    time.Duration // Period over which state transitions are counted
+	Threshold int           // Transitions within Window that mark the mount as flapping (0 disables detection)
+	Unhealthy bool          // Treat a flapping mount as unhealthy for watchdog purposes
+}
+
+// Enabled reports whether the policy turns on flap detection.
+func (p FlapPolicy) Enabled() bool {
+	return p.Threshold > 0 && p.Window > 0
+}
+
+// FlapDetector tracks the state transition history of one mount. It has no clock
+// of its own: callers pass the time of every transition and evaluation (the check
+// timestamp), which keeps it deterministic and easy to test. It is not safe for
+// concurrent use; Mount guards it with its own lock.
+type FlapDetector struct {
+	policy      FlapPolicy
+	transitions []time.Time // Transition times within the window, oldest first
+	flapping    bool
+}
+
+// NewFlapDetector creates a detector for the given policy.
+func NewFlapDetector(policy FlapPolicy) *FlapDetector {
+	return &FlapDetector{policy: policy}
+}
+
+// Record adds a state transition that happened at the given time.
+func (d *FlapDetector) Record(at time.Time) {
+	d.transitions = append(d.transitions, at)
+}
+
+// Evaluate drops transitions that fell out of the window ending at now and
+// updates the flapping state. The high/low thresholds damp the flapping state
+// itself, so a mount near the threshold does not toggle in and out of it.
+// It returns whether the mount is flapping.
+func (d *FlapDetector) Evaluate(now time.Time) bool {
+	cutoff := now.Add(-d.policy.Window)
+	keep := 0
+	for keep < len(d.transitions) && !d.transitions[keep].After(cutoff) {
+		keep++
+	}
+	d.transitions = d.transitions[keep:]
+
+	count := len(d.transitions)
+	switch {
+	case !d.flapping && count >= d.policy.Threshold:
+		d.flapping = true
+	case d.flapping && count < (d.policy.Threshold+1)/2:
+		d.flapping = false
+	}
+	return d.flapping
+}
+
+// Flapping reports the flapping state as of the last Evaluate.
+func (d *FlapDetector) Flapping() bool {
+	return d.flapping
+}
+
+// Transitions returns the number of transitions in the window as of the last Evaluate.
+func (d *FlapDetector) Transitions() int {
+	return len(d.transitions)
+}
@@ -0,0 +1,66 @@
+package health_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/matryer/is"
+)
+
+func TestFlapDetector(t *testing.T) {
+	is := is.New(t)
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	d := health.NewFlapDetector(health.FlapPolicy{Window: 10 * time.Minute, Threshold: 4})
+
+	for i := 0; i < 3; i++ {
+		d.Record(start.Add(time.Duration(i) * time.Minute))
+	}
+	is.True(!d.Evaluate(start.Add(2 * time.Minute))) // three transitions are below threshold
+
+	d.Record(start.Add(3 * time.Minute))
+	is.True(d.Evaluate(start.Add(3 * time.Minute))) // fourth transition starts flapping
+	is.Equal(d.Transitions(), 4)                    // all transitions in window
+
+	is.True(d.Evaluate(start.Add(11*time.Minute + 30*time.Second))) // two left: still flapping (hysteresis)
+	is.Equal(d.Transitions(), 2)                                    // oldest transitions expired
+
+	is.True(!d.Evaluate(start.Add(12*time.Minute + 30*time.Second))) // one left: stopped flapping
+	is.True(!d.Flapping())                                           // state kept until next evaluation
+}
+
+func TestMount_UpdateState_Flapping(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 1)
+	mount.FlapPolicy = health.FlapPolicy{Window: time.Hour, Threshold: 3}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	check := func(success bool) {
+		now = now.Add(30 * time.Second)
+		result := &health.CheckResult{Mount: mount, Timestamp: now, Success: success}
+		if !success {
+			result.Error = errors.New("read timeout")
+		}
+		mount.UpdateState(result, 1)
+	}
+
+	check(true) // unknown -> healthy is not counted
+	check(false)
+	check(true)
+	is.True(!mount.IsFlapping()) // two transitions
+
+	check(false)
+	is.True(mount.IsFlapping()) // third transition within the window
+
+	snapshot := mount.Snapshot()
+	is.True(snapshot.Flapping)        // flapping in snapshot
+	is.Equal(snapshot.Transitions, 3) // transitions in window
+
+	// A stable mount stops flapping once the transitions leave the window
+	now = now.Add(time.Hour)
+	check(false)
+	is.True(!mount.IsFlapping()) // stopped flapping
+}
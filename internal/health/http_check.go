@@ -0,0 +1,145 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ErrUnexpectedHTTPStatus indicates the HTTP backend responded with a status code
+// that is not in the expected set.
+var ErrUnexpectedHTTPStatus = errors.New("unexpected http status")
+
+// maxHTTPBodyBytes caps how much of a response body an http check reads when
+// looking for the expected substring. WebDAV PROPFIND responses for large
+// directories can be huge; the expected marker should appear early.
+const maxHTTPBodyBytes = 1 << 20
+
+// HTTP methods supported by the http check.
+const (
+	HTTPMethodGet      = http.MethodGet
+	HTTPMethodPropfind = "PROPFIND"
+)
+
+// HTTPSpec describes the request an http check sends and the response it expects.
+type HTTPSpec struct {
+	URL            string `json:"url"`            // URL of the backend, e.g. the WebDAV server behind the mount
+	Method         string `json:"method"`         // GET (default) or PROPFIND
+	Username       string `json:"username"`       // Basic auth username (optional)
+	Password       string `json:"password"`       // Basic auth password (optional)
+	ExpectedStatus []int  `json:"expectedStatus"` // Accepted status codes (empty = any 2xx)
+	BodyContains   string `json:"bodyContains"`   // Substring the response body must contain (optional)
+}
+
+// httpClient is shared by all http checks so connections to the backend are reused.
+// Timeouts come from the check context.
+var httpClient = &http.Client{}
+
+// httpCheck probes the HTTP (typically WebDAV) backend behind a mount.
+type httpCheck struct{}
+
+func (httpCheck) Decode(options json.RawMessage) (any, error) {
+	h, err := decodeOptions[HTTPSpec](options)
+	if err != nil {
+		return nil, err
+	}
+	if h.URL == "" {
+		return nil, fmt.Errorf("http check requires url")
+	}
+	u, err := url.Parse(h.URL)
+	if err != nil {
+		return nil, fmt.Errorf("url is not valid: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("url must be an absolute http or https URL, got %q", u.Redacted())
+	}
+	if h.Method != "" && h.Method != HTTPMethodGet && h.Method != HTTPMethodPropfind {
+		return nil, fmt.Errorf("method must be one of: GET, PROPFIND (got %q)", h.Method)
+	}
+	if h.Password != "" && h.Username == "" {
+		return nil, fmt.Errorf("password requires username")
+	}
+	for _, code := range h.ExpectedStatus {
+		if code < 100 || code > 599 {
+			return nil, fmt.Errorf("expectedStatus entries must be between 100 and 599, got %d", code)
+		}
+	}
+	return h, nil
+}
+
+func (httpCheck) Run(ctx context.Context, _ string, spec *CheckSpec) (ProbeResult, error) {
+	h := settingsOf[HTTPSpec](spec)
+	method := h.Method
+	if method == "" {
+		method = HTTPMethodGet
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, h.URL, nil)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+	if method == HTTPMethodPropfind {
+		// Only the collection itself; a full listing of a debrid library is expensive
+		req.Header.Set("Depth", "0")
+	}
+	if h.Username != "" {
+		req.SetBasicAuth(h.Username, h.Password)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+	defer resp.Body.Close()
+
+	if !isExpectedStatus(resp.StatusCode, h.ExpectedStatus) {
+		// Drain a little of the body so the connection can be reused
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+		return ProbeResult{}, fmt.Errorf("%w: %s %s returned %s", ErrUnexpectedHTTPStatus, method, redactURL(h.URL), resp.Status)
+	}
+
+	if h.BodyContains != "" {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPBodyBytes))
+		if err != nil {
+			return ProbeResult{}, fmt.Errorf("reading response body: %w", err)
+		}
+		if !bytes.Contains(body, []byte(h.BodyContains)) {
+			return ProbeResult{}, fmt.Errorf("%w: response body of %s %s does not contain %q",
+				ErrContentMismatch, method, redactURL(h.URL), h.BodyContains)
+		}
+	}
+
+	return ProbeResult{}, nil
+}
+
+// Dependency identifies the probed backend by its URL, without credentials.
+func (httpCheck) Dependency(spec *CheckSpec) string {
+	return redactURL(settingsOf[HTTPSpec](spec).URL)
+}
+
+// isExpectedStatus reports whether code is an accepted response status.
+func isExpectedStatus(code int, expected []int) bool {
+	if len(expected) == 0 {
+		return code >= 200 && code < 300
+	}
+	for _, c := range expected {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL returns rawURL with any password replaced, for logs and status output.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,157 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/matryer/is"
+)
+
+// newWebDAVServer returns a test server that answers like a minimal WebDAV backend.
+func newWebDAVServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); ok && (user != "plex" || pass != "secret") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == "PROPFIND" && r.Header.Get("Depth") == "0":
+			w.WriteHeader(http.StatusMultiStatus)
+			fmt.Fprint(w, `<?xml version="1.0"?><d:multistatus xmlns:d="DAV:"><d:response><d:href>/dav/</d:href></d:response></d:multistatus>`)
+		case r.Method == http.MethodGet && r.URL.Path == "/down":
+			w.WriteHeader(http.StatusBadGateway)
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, "zurg ok")
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestChecker_HTTPCheck(t *testing.T) {
+	srv := newWebDAVServer(t)
+
+	tests := []struct {
+		name     string
+		spec     health.HTTPSpec
+		wantPass bool
+		wantErr  error
+	}{
+		{"get", health.HTTPSpec{URL: srv.URL + "/"}, true, nil},
+		{"get with body", health.HTTPSpec{URL: srv.URL + "/", BodyContains: "zurg ok"}, true, nil},
+		{"body mismatch", health.HTTPSpec{URL: srv.URL + "/", BodyContains: "rclone"}, false, health.ErrContentMismatch},
+		{"propfind", health.HTTPSpec{URL: srv.URL + "/dav/", Method: "PROPFIND", BodyContains: "multistatus"}, true, nil},
+		{"server error", health.HTTPSpec{URL: srv.URL + "/down"}, false, health.ErrUnexpectedHTTPStatus},
+		{"expected error status", health.HTTPSpec{URL: srv.URL + "/down", ExpectedStatus: []int{502}}, true, nil},
+		{"basic auth", health.HTTPSpec{URL: srv.URL + "/", Username: "plex", Password: "secret"}, true, nil},
+		{"wrong password", health.HTTPSpec{URL: srv.URL + "/", Username: "plex", Password: "wrong"}, false, health.ErrUnexpectedHTTPStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			mount := health.NewMountWithCheckType("", t.TempDir(), "", health.CheckTypeHTTP, 3)
+			mount.Options = checkOptions(t, tt.spec)
+			is.NoErr(health.DecodeCheckSpec(&mount.CheckSpec)) // spec should be valid
+			checker := health.NewChecker(5 * time.Second)
+
+			result := checker.Check(context.Background(), mount)
+
+			is.Equal(result.Success, tt.wantPass) // check outcome
+			if tt.wantErr != nil {
+				is.True(errors.Is(result.Error, tt.wantErr)) // failure cause
+			}
+		})
+	}
+}
+
+func TestChecker_HTTPCheck_Timeout(t *testing.T) {
+	is := is.New(t)
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", health.CheckTypeHTTP, 3)
+	mount.Settings = health.HTTPSpec{URL: srv.URL}
+	checker := health.NewChecker(200 * time.Millisecond)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                                   // hung backend should fail
+	is.True(errors.Is(result.Error, context.DeadlineExceeded)) // timeout error
+}
+
+func TestChecker_HTTPCheck_ReportsDependency(t *testing.T) {
+	is := is.New(t)
+
+	srv := newWebDAVServer(t)
+	tmpDir := t.TempDir()
+
+	mount := health.NewMount("", tmpDir, ".health-check", 3)
+	httpSpec := health.NewCheckSpec(tmpDir, "", health.CheckTypeHTTP)
+	httpSpec.Settings = health.HTTPSpec{URL: strings.Replace(srv.URL, "http://", "http://plex:secret@", 1) + "/"}
+	mount.Checks = []health.CheckSpec{
+		httpSpec,
+		health.NewCheckSpec(tmpDir, ".health-check", health.CheckTypeCanary),
+	}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+	mount.UpdateState(result, 3)
+
+	is.True(!result.Success)                                            // canary file is missing
+	is.Equal(len(result.Dependencies), 1)                               // http check is a dependency
+	is.True(result.Dependencies[0].Success)                             // backend is up
+	is.True(!strings.Contains(result.Dependencies[0].Target, "secret")) // target has no password
+
+	snap := mount.Snapshot()
+	is.Equal(len(snap.Dependencies), 1)         // dependency is in the snapshot
+	is.Equal(snap.Dependencies[0].Status, "up") // backend up while the mount fails
+}
+
+func TestHTTPCheck_Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    health.HTTPSpec
+		wantErr bool
+	}{
+		{"get", health.HTTPSpec{URL: "http://zurg:9999/dav/"}, false},
+		{"propfind https", health.HTTPSpec{URL: "https://webdav.example.com/", Method: "PROPFIND"}, false},
+		{"missing url", health.HTTPSpec{}, true},
+		{"relative url", health.HTTPSpec{URL: "/dav/"}, true},
+		{"unsupported scheme", health.HTTPSpec{URL: "ftp://zurg/"}, true},
+		{"unsupported method", health.HTTPSpec{URL: "http://zurg/", Method: "POST"}, true},
+		{"password without username", health.HTTPSpec{URL: "http://zurg/", Password: "x"}, true},
+		{"invalid status", health.HTTPSpec{URL: "http://zurg/", ExpectedStatus: []int{42}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			spec := health.NewCheckSpec("/mnt/test", "", health.CheckTypeHTTP)
+			spec.Options = checkOptions(t, tt.spec)
+
+			err := health.DecodeCheckSpec(&spec)
+			is.Equal(err != nil, tt.wantErr) // validation outcome
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package health
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrProbeHung indicates a check was not run because a probe started by an
+// earlier check of the mount is still blocked, typically on hung mount I/O.
+var ErrProbeHung = errors.New("previous probe still hung")
+
+// probe tracks a check goroutine of a mount until it returns and the probe
+// helpers it started were reaped.
+type probe struct {
+	started  time.Time
+	stuck    bool // The check gave up waiting for the probe, which has not returned yet
+	returned bool // The check goroutine returned
+	helpers  int  // Probe helper processes started by the check that were not reaped yet
+}
+
+// startProbe registers a new probe goroutine for the mount. If a probe of an
+// earlier check is stuck, no probe is registered and ok is false; stuckSince is
+// the start time of the oldest stuck probe.
+func (m *Mount) startProbe(at time.Time) (id uint64, stuckSince time.Time, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if since, stuck := m.oldestStuckProbeLocked(); stuck > 0 {
+		return 0, since, false
+	}
+	if m.probes == nil {
+		m.probes = make(map[uint64]*probe)
+	}
+	m.probeSeq++
+	m.probes[m.probeSeq] = &probe{started: at}
+	return m.probeSeq, time.Time{}, true
+}
+
+// finishProbe records that a probe goroutine returned. The probe is forgotten
+// once its probe helpers were reaped as well.
+func (m *Mount) finishProbe(id uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, running := m.probes[id]; running {
+		p.returned = true
+		m.releaseProbeLocked(id, p)
+	}
+}
+
+// helperStarted records that a probe started a probe helper process.
+func (m *Mount) helperStarted(id uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, running := m.probes[id]; running {
+		p.helpers++
+	}
+}
+
+// helperReaped records that a probe helper process exited and was reaped. A
+// helper in uninterruptible sleep outlives its check, so until then the probe
+// counts as stuck even though its goroutine returned.
+func (m *Mount) helperReaped(id uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, running := m.probes[id]; running {
+		p.helpers--
+		m.releaseProbeLocked(id, p)
+	}
+}
+
+// releaseProbeLocked forgets a probe whose goroutine returned and whose probe
+// helpers were all reaped. Must be called with m.mu held.
+func (m *Mount) releaseProbeLocked(id uint64, p *probe) {
+	if p.returned && p.helpers == 0 {
+		delete(m.probes, id)
+	}
+}
+
+// abandonProbe records that a check stopped waiting for its probe. If the probe
+// has not returned yet it is stuck, and later checks fail with ErrProbeHung
+// until it returns.
+func (m *Mount) abandonProbe(id uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, running := m.probes[id]; running {
+		p.stuck = true
+	}
+}
+
+// oldestStuckProbeLocked returns the number of stuck probes and the start time
+// of the oldest one. Must be called with m.mu held.
+func (m *Mount) oldestStuckProbeLocked() (time.Time, int) {
+	var oldest time.Time
+	count := 0
+	for _, p := range m.probes {
+		if !p.stuck {
+			continue
+		}
+		count++
+		if oldest.IsZero() || p.started.Before(oldest) {
+			oldest = p.started
+		}
+	}
+	return oldest, count
+}
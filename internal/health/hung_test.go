@@ -0,0 +1,50 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/matryer/is"
+)
+
+// hungMountCheck blocks like a read on a hung mount until it is released.
+var hungMountCheck = health.NewBlockingCheckForTesting()
+
+func init() {
+	health.RegisterCheckType("test-blocking", hungMountCheck)
+}
+
+func TestChecker_HungProbeIsNotRepeated(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", "test-blocking", 3)
+	checker := health.NewChecker(50 * time.Millisecond)
+
+	result := checker.Check(context.Background(), mount)
+	is.True(errors.Is(result.Error, context.DeadlineExceeded)) // first check times out
+	is.Equal(mount.Snapshot().StuckProbes, 1)                  // its probe is still blocked
+
+	start := time.Now()
+	result = checker.Check(context.Background(), mount)
+	is.True(time.Since(start) < 50*time.Millisecond)      // fails without waiting for the timeout
+	is.True(errors.Is(result.Error, health.ErrProbeHung)) // previous probe still hung
+	is.Equal(result.Reason, health.ReasonProbeHung)       // classified as probe_hung
+	is.Equal(mount.Snapshot().StuckProbes, 1)             // no additional probe started
+	is.True(!mount.Snapshot().StuckSince.After(start))    // age counts from the first probe
+
+	mount.UpdateState(result, 3)
+	is.Equal(mount.GetFailureCount(), 1) // counts toward unhealthy
+
+	hungMountCheck.Release() // the mount recovers and the blocked read returns
+	deadline := time.Now().Add(5 * time.Second)
+	for mount.Snapshot().StuckProbes > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	is.Equal(mount.Snapshot().StuckProbes, 0) // probe returned
+
+	result = checker.Check(context.Background(), mount)
+	is.NoErr(result.Error) // checks run again
+}
@@ -0,0 +1,63 @@
+package health
+
+import "time"
+
+// AdaptiveInterval configures how often a mount is checked depending on its
+// state: more often while it is failing, to confirm an outage or a recovery
+// sooner, and optionally less often once it has been healthy for a while.
+type AdaptiveInterval struct {
+	Degraded    time.Duration // Interval while the mount is degraded, unhealthy or recovering (0 = base interval)
+	Stable      time.Duration // Interval once the mount is stable (0 = base interval)
+	StableAfter int           // Consecutive passing checks of a healthy mount before it is stable
+}
+
+// IntervalMode identifies which check interval applies to a mount.
+type IntervalMode int
+
+const (
+	// IntervalNormal uses the monitor's base check interval.
+	IntervalNormal IntervalMode = iota
+	// IntervalDegraded uses AdaptiveInterval.Degraded while the mount is failing.
+	IntervalDegraded
+	// IntervalStable uses AdaptiveInterval.Stable after sustained health.
+	IntervalStable
+)
+
+// String returns the name of the interval mode.
+func (m IntervalMode) String() string {
+	switch m {
+	case IntervalDegraded:
+		return "degraded"
+	case IntervalStable:
+		return "stable"
+	default:
+		return "normal"
+	}
+}
+
+// NextInterval returns the interval until the mount's next check, given the
+// monitor's base interval, and records it for reporting. changed reports
+// whether the interval mode differs from the one used after the previous check.
+func (m *Mount) NextInterval(base time.Duration) (interval time.Duration, mode IntervalMode, changed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mode = IntervalNormal
+	interval = base
+	switch {
+	case m.Status == StatusDegraded || m.Status == StatusUnhealthy || m.Status == StatusRecovering:
+		if m.Intervals.Degraded > 0 {
+			mode, interval = IntervalDegraded, m.Intervals.Degraded
+		}
+	case m.Status == StatusHealthy && m.Intervals.Stable > 0 && m.SuccessCount >= m.Intervals.StableAfter:
+		mode, interval = IntervalStable, m.Intervals.Stable
+	}
+
+	changed = m.interval != 0 && mode != m.intervalMode
+	if m.interval == 0 || changed {
+		m.intervalSince = m.LastCheck
+	}
+	m.interval = interval
+	m.intervalMode = mode
+	return interval, mode, changed
+}
@@ -0,0 +1,66 @@
+package health_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/matryer/is"
+)
+
+func TestMount_NextInterval(t *testing.T) {
+	is := is.New(t)
+
+	base := 30 * time.Second
+	mount := health.NewMount("", "/mnt/test", ".health-check", 3)
+	mount.Intervals = health.AdaptiveInterval{Degraded: 5 * time.Second, Stable: 2 * time.Minute, StableAfter: 3}
+	now := time.Now()
+	check := func(success bool) {
+		result := &health.CheckResult{Mount: mount, Timestamp: now, Success: success}
+		if !success {
+			result.Error = errors.New("read failed")
+		}
+		mount.UpdateState(result, 3)
+		now = now.Add(time.Second)
+	}
+
+	check(true)
+	interval, mode, changed := mount.NextInterval(base)
+	is.Equal(interval, base)              // healthy but not yet stable
+	is.Equal(mode, health.IntervalNormal) // normal mode
+	is.True(!changed)                     // first interval is not a change
+
+	check(false)
+	interval, mode, changed = mount.NextInterval(base)
+	is.Equal(interval, 5*time.Second)                               // degraded interval
+	is.Equal(mode, health.IntervalDegraded)                         // degraded mode
+	is.True(changed)                                                // switched from normal
+	is.Equal(mount.Snapshot().IntervalSince, now.Add(-time.Second)) // switched at the failed check
+
+	check(true)
+	check(true)
+	interval, _, _ = mount.NextInterval(base)
+	is.Equal(interval, base) // healthy again, two passes in a row
+
+	check(true)
+	interval, mode, changed = mount.NextInterval(base)
+	is.Equal(interval, 2*time.Minute)     // stable after three passes in a row
+	is.Equal(mode, health.IntervalStable) // stable mode
+	is.True(changed)                      // switched from normal
+
+	snapshot := mount.Snapshot()
+	is.Equal(snapshot.Interval, 2*time.Minute)             // effective interval reported
+	is.Equal(snapshot.IntervalMode, health.IntervalStable) // mode reported
+}
+
+func TestMount_NextInterval_Disabled(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 1)
+	mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Error: errors.New("read failed")}, 1)
+
+	interval, mode, _ := mount.NextInterval(30 * time.Second)
+	is.Equal(interval, 30*time.Second)    // no degraded interval configured
+	is.Equal(mode, health.IntervalNormal) // base interval applies
+}
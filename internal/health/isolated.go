@@ -0,0 +1,208 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// ProbeSubcommand is the hidden command-line argument that starts the binary as
+// a probe helper for mounts with IsolateProbes set. Binaries that monitor such
+// mounts must call RunProbeHelper when started with it, before parsing flags.
+const ProbeSubcommand = "__probe"
+
+// probeKillGrace bounds how long a check waits for a killed probe helper to
+// exit. A helper in uninterruptible sleep (D state) cannot die until the kernel
+// returns from its I/O; the check does not wait for that, but its probe stays
+// stuck until the helper is reaped.
+const probeKillGrace = time.Second
+
+// probeRef identifies the probe of a mount that runs a check in probe helpers.
+type probeRef struct {
+	mount *Mount
+	id    uint64
+}
+
+// probeRequest is sent to the probe helper on stdin.
+type probeRequest struct {
+	Path    string
+	Spec    CheckSpec
+	Timeout time.Duration // Remaining time until the check's deadline (0 = none)
+}
+
+// probeResponse is returned by the probe helper on stdout.
+type probeResponse struct {
+	BytesRead int64
+	MountInfo *MountInfo
+	Rclone    *RcloneStats
+	Error     string // Error message (empty if the check passed)
+	Reason    string // FailureReason name of the error
+}
+
+// RunProbeHelper runs a single check described by a request read from stdin and
+// writes the result to stdout. It returns the process exit code: 0 whenever a
+// result was written, even for a failed check.
+func RunProbeHelper(stdin io.Reader, stdout io.Writer) int {
+	var req probeRequest
+	if err := json.NewDecoder(stdin).Decode(&req); err != nil {
+		fmt.Fprintf(os.Stderr, "probe helper: invalid request: %v\n", err)
+		return 2
+	}
+	// Settings are not sent, the helper decodes them from the check's options
+	if err := DecodeCheckSpec(&req.Spec); err != nil {
+		fmt.Fprintf(os.Stderr, "probe helper: invalid check settings: %v\n", err)
+		return 2
+	}
+
+	ctx := context.Background()
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	outcome := runCheck(ctx, req.Path, &req.Spec, nil)
+	resp := probeResponse{
+		BytesRead: outcome.bytesRead,
+		MountInfo: outcome.mountInfo,
+		Rclone:    outcome.rclone,
+	}
+	if outcome.err != nil {
+		resp.Error = outcome.err.Error()
+		resp.Reason = ClassifyFailure(outcome.err).String()
+	}
+	if err := json.NewEncoder(stdout).Encode(resp); err != nil {
+		fmt.Fprintf(os.Stderr, "probe helper: writing result: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// runIsolated runs a check in a probe helper subprocess, a re-exec of the
+// running binary. When ctx is done the helper's process group is killed, so a
+// read blocked on a hung mount never blocks the monitor itself.
+func runIsolated(ctx context.Context, path string, spec *CheckSpec, probe probeRef) probeOutcome {
+	start := time.Now()
+	outcome := probeOutcome{checkType: spec.CheckType}
+	resp, err := callProbeHelper(ctx, path, spec, probe)
+	outcome.duration = time.Since(start)
+	if err != nil {
+		outcome.err = err
+		return outcome
+	}
+	outcome.bytesRead = resp.BytesRead
+	outcome.mountInfo = resp.MountInfo
+	outcome.rclone = resp.Rclone
+	if resp.Error != "" {
+		reason, _ := ParseFailureReason(resp.Reason)
+		outcome.err = &probeError{msg: resp.Error, reason: reason}
+	}
+	return outcome
+}
+
+// callProbeHelper starts the probe helper, sends it the check and waits for its
+// result or for ctx to be done. The helper counts toward probe until it is
+// reaped, which may be long after callProbeHelper returned.
+func callProbeHelper(ctx context.Context, path string, spec *CheckSpec, probe probeRef) (*probeResponse, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("probe helper: %w", err)
+	}
+	req := probeRequest{Path: path, Spec: *spec}
+	if deadline, ok := ctx.Deadline(); ok {
+		req.Timeout = time.Until(deadline)
+	}
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("probe helper: %w", err)
+	}
+
+	var stdout bytes.Buffer
+	stderr := &limitedBuffer{limit: execOutputLimit}
+	cmd := exec.Command(exe, ProbeSubcommand)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = stderr
+	// Its own process group, so exec checks run by the helper are killed with it
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("probe helper: %w", err)
+	}
+
+	probe.mount.helperStarted(probe.id)
+
+	exited := make(chan error, 1)
+	waitHook := probeWaitHook.Load()
+	go func() {
+		if waitHook != nil {
+			(*waitHook)()
+		}
+		err := cmd.Wait()
+		probe.mount.helperReaped(probe.id) // Before sending, so a returned helper is never reported stuck
+		exited <- err
+	}()
+
+	select {
+	case err = <-exited:
+	case <-ctx.Done():
+		_ = killProcessGroup(cmd)
+		select {
+		case <-exited:
+		case <-time.After(probeKillGrace):
+			// Still stuck in the kernel; the Wait goroutine reaps it once the I/O
+			// returns and only then releases the probe
+		}
+		return nil, fmt.Errorf("probe helper killed: %w", ctx.Err())
+	}
+
+	var resp probeResponse
+	if decodeErr := json.Unmarshal(stdout.Bytes(), &resp); decodeErr != nil {
+		if err == nil {
+			err = decodeErr
+		}
+		if msg := stderr.String(); msg != "" {
+			return nil, fmt.Errorf("probe helper failed: %v: stderr: %s", err, msg)
+		}
+		return nil, fmt.Errorf("probe helper failed: %v", err)
+	}
+	return &resp, nil
+}
+
+// probeError is a check error reported by a probe helper. The original error
+// cannot cross the process boundary, so it unwraps to a representative error of
+// its failure reason, which keeps ClassifyFailure and errors.Is working.
+type probeError struct {
+	msg    string
+	reason FailureReason
+}
+
+func (e *probeError) Error() string { return e.msg }
+
+func (e *probeError) Unwrap() error { return reasonErrors[e.reason] }
+
+// reasonErrors maps failure reasons to an error ClassifyFailure classifies as
+// that reason.
+var reasonErrors = map[FailureReason]error{
+	ReasonTimeout:            context.DeadlineExceeded,
+	ReasonCanceled:           context.Canceled,
+	ReasonNotConnected:       syscall.ENOTCONN,
+	ReasonStale:              syscall.ESTALE,
+	ReasonIOError:            syscall.EIO,
+	ReasonNotFound:           fs.ErrNotExist,
+	ReasonPermission:         fs.ErrPermission,
+	ReasonContentMismatch:    ErrContentMismatch,
+	ReasonListingMismatch:    ErrListingMismatch,
+	ReasonNotMountpoint:      ErrNotMountpoint,
+	ReasonUnexpectedFSType:   ErrUnexpectedFSType,
+	ReasonExecFailed:         ErrExecFailed,
+	ReasonHTTPStatus:         ErrUnexpectedHTTPStatus,
+	ReasonRcloneMountMissing: ErrRcloneMountMissing,
+	ReasonRcloneUploadQueue:  ErrRcloneUploadQueue,
+}
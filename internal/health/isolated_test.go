@@ -0,0 +1,148 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/cscheib/debrid-mount-monitor/internal/testutil"
+	"github.com/matryer/is"
+)
+
+// TestMain lets the test binary act as the probe helper for isolated checks,
+// which re-exec the running binary.
+func TestMain(m *testing.M) {
+	if len(os.Args) == 2 && os.Args[1] == health.ProbeSubcommand {
+		os.Exit(health.RunProbeHelper(os.Stdin, os.Stdout))
+	}
+	os.Exit(m.Run())
+}
+
+// unkillableCheck is a check type that ignores context cancellation and never
+// returns on its own, like a read from a mount stuck in the kernel.
+type unkillableCheck struct{}
+
+func init() {
+	health.RegisterCheckType("test-unkillable", unkillableCheck{})
+}
+
+func (unkillableCheck) Decode(json.RawMessage) (any, error) { return nil, nil }
+
+func (unkillableCheck) Run(context.Context, string, *health.CheckSpec) (health.ProbeResult, error) {
+	time.Sleep(time.Hour)
+	return health.ProbeResult{}, nil
+}
+
+func TestChecker_IsolatedProbe(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	mount := health.NewMount("", tmpDir, ".health-check", 3)
+	mount.IsolateProbes = true
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+	is.True(!result.Success)                                         // canary file missing
+	is.True(errors.Is(result.Error, fs.ErrNotExist))                 // error identity survives the process boundary
+	is.Equal(result.Reason, health.ReasonNotFound)                   // classified as not_found
+	is.True(strings.Contains(result.Error.Error(), ".health-check")) // original message kept
+
+	is.NoErr(os.WriteFile(filepath.Join(tmpDir, ".health-check"), []byte("ok"), 0644)) // create canary file
+
+	result = checker.Check(context.Background(), mount)
+	is.NoErr(result.Error) // isolated check passes
+	is.True(result.Success)
+}
+
+// TestChecker_IsolatedProbe_Settings verifies that the probe helper decodes a
+// check's type-specific settings from its options.
+func TestChecker_IsolatedProbe_Settings(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(tmpDir, ".health-check"), []byte("stale"), 0644)) // create canary file
+
+	mount := health.NewMountWithCheckType("", tmpDir, ".health-check", health.CheckTypeContent, 3)
+	mount.Options = json.RawMessage(`{"expectedContent": "ok"}`)
+	is.NoErr(health.DecodeCheckSpec(&mount.CheckSpec)) // settings should decode
+	mount.IsolateProbes = true
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+	is.True(!result.Success)                              // content differs from expectedContent
+	is.Equal(result.Reason, health.ReasonContentMismatch) // helper compared against the decoded settings
+}
+
+func TestChecker_IsolatedProbe_KilledOnTimeout(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", "test-unkillable", 3)
+	mount.IsolateProbes = true
+	checker := health.NewChecker(200 * time.Millisecond)
+
+	start := time.Now()
+	result := checker.Check(context.Background(), mount)
+
+	is.True(time.Since(start) < 2*time.Second)                 // returns without waiting for the probe
+	is.True(errors.Is(result.Error, context.DeadlineExceeded)) // reported as a timeout
+	is.Equal(result.Reason, health.ReasonTimeout)              // classified as timeout
+
+	// The helper was killed, so unlike an in-process probe nothing is left stuck
+	deadline := time.Now().Add(5 * time.Second)
+	for mount.Snapshot().StuckProbes > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	is.Equal(mount.Snapshot().StuckProbes, 0) // no stuck probe
+
+	result = checker.Check(context.Background(), mount)
+	is.Equal(result.Reason, health.ReasonTimeout) // next check runs instead of failing as probe_hung
+}
+
+// TestChecker_IsolatedProbe_NotReaped verifies that a killed probe helper that
+// cannot be reaped, like one stuck in uninterruptible sleep, keeps the mount's
+// probe stuck until it is reaped, even though the check gave up on it.
+func TestChecker_IsolatedProbe_NotReaped(t *testing.T) {
+	is := is.New(t)
+
+	release := make(chan struct{})
+	restore := health.SetProbeWaitHookForTesting(func() { <-release })
+	defer restore()
+
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", "test-unkillable", 3)
+	mount.IsolateProbes = true
+	checker := health.NewChecker(200 * time.Millisecond)
+
+	returned := make(chan struct{})
+	restoreReturn := health.SetProbeReturnHookForTesting(func() { close(returned) })
+
+	result := checker.Check(context.Background(), mount)
+	is.Equal(result.Reason, health.ReasonTimeout) // reported as a timeout
+
+	// The check goroutine gives up on the helper after the kill grace period
+	<-returned
+	restoreReturn()
+	is.Equal(mount.Snapshot().StuckProbes, 1) // helper not reaped, so the probe is still stuck
+
+	result = checker.Check(context.Background(), mount)
+	is.Equal(result.Reason, health.ReasonProbeHung) // no new helper while the old one is not reaped
+
+	close(release)
+	testutil.PollUntil(t, 5*time.Second, func() bool {
+		return mount.Snapshot().StuckProbes == 0
+	})
+
+	result = checker.Check(context.Background(), mount)
+	is.Equal(result.Reason, health.ReasonTimeout) // next check runs once the helper was reaped
+
+	// Leave no helper of this test running into other tests
+	testutil.PollUntil(t, 5*time.Second, func() bool {
+		return mount.Snapshot().StuckProbes == 0
+	})
+}
@@ -0,0 +1,114 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrSlowCheck indicates a check passed but it and the checks before it took
+// longer than the mount's latency fail threshold, so it is counted as a failure.
+var ErrSlowCheck = errors.New("check too slow")
+
+// latencySamples is the number of recent check durations kept per mount for the
+// rolling latency percentiles.
+const latencySamples = 100
+
+// defaultLatencyFailAfter is the number of consecutive checks slower than the
+// fail threshold before they count as failures, if LatencyPolicy.FailAfter is 0.
+const defaultLatencyFailAfter = 3
+
+// LatencyPolicy configures latency-based degradation for a mount. A check that is
+// close to the read timeout is a warning sign even though it passed.
+type LatencyPolicy struct {
+	Warn      time.Duration // Passing checks slower than this mark the mount degraded without counting as failures (0 disables)
+	Fail      time.Duration // Passing checks slower than this count as failed checks once FailAfter did in a row (0 disables)
+	FailAfter int           // Consecutive checks slower than Fail before they count as failed checks (0 = 3)
+}
+
+// LatencyStats summarizes the durations of a mount's recent checks.
+type LatencyStats struct {
+	Samples int           // Number of checks the percentiles cover
+	P50     time.Duration // Median check duration
+	P95     time.Duration // 95th percentile check duration
+}
+
+// failAfter returns the number of consecutive checks slower than Fail before
+// they count as failed checks.
+func (p LatencyPolicy) failAfter() int {
+	if p.FailAfter <= 0 {
+		return defaultLatencyFailAfter
+	}
+	return p.FailAfter
+}
+
+// exceedsFail reports whether a passing check exceeded the policy's fail threshold.
+func (p LatencyPolicy) exceedsFail(result *CheckResult) bool {
+	return result.Success && p.Fail > 0 && result.Duration > p.Fail
+}
+
+// applyLatencyPolicy turns a passing check that exceeded the policy's fail
+// threshold into a failed check, once slowChecks consecutive checks (including
+// this one) did. A single slow check only marks the mount degraded.
+func applyLatencyPolicy(result *CheckResult, policy LatencyPolicy, slowChecks int) {
+	if !policy.exceedsFail(result) || slowChecks < policy.failAfter() {
+		return
+	}
+	result.Success = false
+	result.Error = fmt.Errorf("%w: took %s, limit %s (%d checks in a row)",
+		ErrSlowCheck, result.Duration.Round(time.Millisecond), policy.Fail, slowChecks)
+}
+
+// isSlow reports whether a passing check exceeded the policy's warn threshold,
+// or its fail threshold without having been counted as a failure yet.
+func (p LatencyPolicy) isSlow(result *CheckResult) bool {
+	return result.Success && (p.Warn > 0 && result.Duration > p.Warn || p.exceedsFail(result))
+}
+
+// countSlowCheck records whether a check exceeded the mount's latency fail
+// threshold and returns the number of consecutive checks that did.
+func (m *Mount) countSlowCheck(result *CheckResult) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Latency.exceedsFail(result) {
+		m.slowChecks++
+	} else {
+		m.slowChecks = 0
+	}
+	return m.slowChecks
+}
+
+// recordLatencyLocked adds a check duration to the mount's rolling latency
+// samples. Must be called with m.mu held.
+func (m *Mount) recordLatencyLocked(d time.Duration) {
+	m.latencies = append(m.latencies, d)
+	if excess := len(m.latencies) - latencySamples; excess > 0 {
+		m.latencies = append(m.latencies[:0], m.latencies[excess:]...)
+	}
+}
+
+// latencyStatsLocked computes percentiles over the mount's recent check
+// durations (nearest-rank method). Must be called with m.mu held.
+func (m *Mount) latencyStatsLocked() LatencyStats {
+	if len(m.latencies) == 0 {
+		return LatencyStats{}
+	}
+	sorted := append([]time.Duration(nil), m.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return LatencyStats{
+		Samples: len(sorted),
+		P50:     percentile(sorted, 50),
+		P95:     percentile(sorted, 95),
+	}
+}
+
+// percentile returns the p-th percentile of sorted durations using the
+// nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,131 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/matryer/is"
+)
+
+func TestMount_UpdateState_SlowCheckDegrades(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 2)
+	mount.Latency = health.LatencyPolicy{Warn: 4 * time.Second}
+	check := func(d time.Duration) *health.StateTransition {
+		return mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: true, Duration: d}, 2)
+	}
+
+	check(time.Second)
+	is.Equal(mount.GetStatus(), health.StatusHealthy) // fast check
+
+	transition := check(4900 * time.Millisecond)
+	is.Equal(mount.GetStatus(), health.StatusDegraded) // slow check degrades
+	is.Equal(transition.Trigger, "slow_check")         // slowness caused the transition
+	is.True(mount.Snapshot().Slow)                     // slowness reported
+
+	for i := 0; i < 3; i++ {
+		check(4900 * time.Millisecond)
+	}
+	is.Equal(mount.GetStatus(), health.StatusDegraded) // sustained slowness alone is never unhealthy
+	is.Equal(mount.GetFailureCount(), 0)               // slow checks do not count as failures
+
+	transition = check(time.Second)
+	is.Equal(mount.GetStatus(), health.StatusHealthy) // fast again
+	is.Equal(transition.Trigger, "check_passed")      // plain passing check
+	is.True(!mount.Snapshot().Slow)                   // no longer slow
+}
+
+func TestMount_UpdateState_SlowCheckAfterFailure(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 1)
+	mount.Latency = health.LatencyPolicy{Warn: time.Second}
+
+	mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Error: errors.New("read failed")}, 1)
+	is.Equal(mount.GetStatus(), health.StatusUnhealthy) // failed past threshold
+
+	transition := mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: true, Duration: 2 * time.Second}, 1)
+	is.Equal(transition.PreviousState, health.StatusUnhealthy) // left unhealthy
+	is.Equal(transition.NewState, health.StatusDegraded)       // passing but slow
+	is.Equal(transition.Trigger, "slow_check")                 // slowness reported as the trigger
+	is.Equal(mount.GetFailureCount(), 0)                       // failures reset
+}
+
+func TestChecker_LatencyFailThreshold(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(tmpDir, ".health-check"), []byte("ok"), 0644)) // create canary file
+
+	mount := health.NewMount("", tmpDir, ".health-check", 3)
+	mount.Latency = health.LatencyPolicy{Fail: time.Nanosecond}
+	checker := health.NewChecker(5 * time.Second)
+
+	for i := 0; i < 2; i++ {
+		result := checker.Check(context.Background(), mount)
+		is.True(result.Success) // slow checks pass until three in a row were slow
+		mount.UpdateState(result, 3)
+	}
+	is.Equal(mount.GetStatus(), health.StatusDegraded) // slow but not failing yet
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                              // third slow check in a row
+	is.True(errors.Is(result.Error, health.ErrSlowCheck)) // slow check error
+	is.Equal(result.Reason, health.ReasonSlow)            // classified as slow
+
+	mount.UpdateState(result, 3)
+	is.Equal(mount.GetFailureCount(), 1) // counts toward unhealthy
+}
+
+// TestChecker_LatencyFailThreshold_SingleSlowCheck tests that a slow check
+// between fast ones never counts as a failure.
+func TestChecker_LatencyFailThreshold_SingleSlowCheck(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(tmpDir, ".health-check"), []byte("ok"), 0644)) // create canary file
+
+	mount := health.NewMount("", tmpDir, ".health-check", 1)
+	mount.Latency = health.LatencyPolicy{Fail: time.Nanosecond, FailAfter: 2}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+	mount.UpdateState(result, 1)
+	is.True(result.Success)                            // a single slow check passes
+	is.Equal(mount.GetStatus(), health.StatusDegraded) // and only degrades the mount
+	is.Equal(mount.GetFailureCount(), 0)               // without counting as a failure
+
+	// A fast check resets the run of slow checks
+	mount.Latency.Fail = time.Hour
+	result = checker.Check(context.Background(), mount)
+	mount.UpdateState(result, 1)
+	is.Equal(mount.GetStatus(), health.StatusHealthy) // fast again
+
+	mount.Latency.Fail = time.Nanosecond
+	result = checker.Check(context.Background(), mount)
+	is.True(result.Success) // first slow check of a new run passes
+}
+
+func TestMount_Snapshot_LatencyPercentiles(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 3)
+	is.Equal(mount.Snapshot().Latency.Samples, 0) // no checks yet
+
+	// 150 checks taking 1ms..150ms; only the last 100 (51ms..150ms) are kept
+	for i := 1; i <= 150; i++ {
+		mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: true, Duration: time.Duration(i) * time.Millisecond}, 3)
+	}
+
+	latency := mount.Snapshot().Latency
+	is.Equal(latency.Samples, 100)              // rolling window size
+	is.Equal(latency.P50, 100*time.Millisecond) // median of 51ms..150ms
+	is.Equal(latency.P95, 145*time.Millisecond) // 95th percentile of 51ms..150ms
+}
@@ -0,0 +1,80 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrListingMismatch indicates the mount directory was readable but did not contain
+// the expected entries, typically because the FUSE daemon died and the empty
+// underlying directory is exposed.
+var ErrListingMismatch = errors.New("listing mismatch")
+
+// listingCheck lists the mount directory and verifies its contents.
+type listingCheck struct{}
+
+func (listingCheck) Decode(options json.RawMessage) (any, error) {
+	l, err := decodeOptions[ListingSpec](options)
+	if err != nil {
+		return nil, err
+	}
+	if l.MinEntries < 0 {
+		return nil, fmt.Errorf("minEntries must be >= 0, got %d", l.MinEntries)
+	}
+	for _, d := range l.ExpectedDirs {
+		name := strings.TrimSuffix(d, "/")
+		if name == "" || name == "." || name == ".." || strings.ContainsRune(name, '/') {
+			return nil, fmt.Errorf("expectedDirs entries must be names of direct subdirectories, got %q", d)
+		}
+	}
+	return l, nil
+}
+
+func (listingCheck) Run(_ context.Context, path string, spec *CheckSpec) (ProbeResult, error) {
+	return ProbeResult{}, checkListing(path, settingsOf[ListingSpec](spec))
+}
+
+// checkListing reads the mount directory and verifies the entry count and
+// presence of expected subdirectories.
+func checkListing(path string, spec ListingSpec) error {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return err
+	}
+
+	minEntries := spec.MinEntries
+	if minEntries <= 0 {
+		minEntries = 1
+	}
+	if len(entries) < minEntries {
+		return fmt.Errorf("%w: found %d entries, expected at least %d", ErrListingMismatch, len(entries), minEntries)
+	}
+
+	if len(spec.ExpectedDirs) == 0 {
+		return nil
+	}
+
+	dirs := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			dirs[e.Name()] = true
+		}
+	}
+
+	var missing []string
+	for _, want := range spec.ExpectedDirs {
+		name := strings.TrimSuffix(want, "/")
+		if !dirs[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: missing expected directories: %s", ErrListingMismatch, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package health
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// mountInfoPath is the kernel's per-process mount table.
+// It is a variable so tests can point the checker at fixture files.
+var mountInfoPath = "/proc/self/mountinfo"
+
+var (
+	// ErrNotMountpoint indicates the mount path exists but nothing is mounted on it,
+	// typically because the FUSE daemon (e.g. rclone) crashed.
+	ErrNotMountpoint = errors.New("not a mountpoint")
+	// ErrUnexpectedFSType indicates the path is mounted, but not with an expected filesystem type.
+	ErrUnexpectedFSType = errors.New("unexpected filesystem type")
+)
+
+// MountInfo is a single entry of /proc/self/mountinfo.
+// See proc(5) for the field definitions.
+type MountInfo struct {
+	MountID      int    // Unique mount identifier
+	ParentID     int    // Mount ID of the parent mount
+	Root         string // Root of the mount within the filesystem
+	MountPoint   string // Mount point relative to the process root
+	Options      string // Per-mount options (e.g. "rw,relatime")
+	FSType       string // Filesystem type (e.g. "fuse.rclone", "nfs4")
+	Source       string // Filesystem-specific source (e.g. "debrid:", "server:/export")
+	SuperOptions string // Per-superblock options
+}
+
+// ParseMountInfo parses the contents of a mountinfo file.
+func ParseMountInfo(r io.Reader) ([]MountInfo, error) {
+	var entries []MountInfo
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		entry, err := parseMountInfoLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("mountinfo line %d: %w", lineNum, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading mountinfo: %w", err)
+	}
+
+	return entries, nil
+}
+
+// parseMountInfoLine parses one mountinfo line:
+//
+//	36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+//	(1)(2)(3)   (4)   (5)      (6)      (7)   (8) (9)   (10)         (11)
+//
+// Field 7 is a variable-length list of optional fields terminated by "-".
+func parseMountInfoLine(line string) (MountInfo, error) {
+	fields := strings.Fields(line)
+
+	sep := -1
+	for i := 6; i < len(fields); i++ {
+		if fields[i] == "-" {
+			sep = i
+			break
+		}
+	}
+	if len(fields) < 7 || sep < 0 || len(fields) < sep+3 {
+		return MountInfo{}, fmt.Errorf("malformed entry %q", line)
+	}
+
+	mountID, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return MountInfo{}, fmt.Errorf("invalid mount ID %q", fields[0])
+	}
+	parentID, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return MountInfo{}, fmt.Errorf("invalid parent ID %q", fields[1])
+	}
+
+	entry := MountInfo{
+		MountID:    mountID,
+		ParentID:   parentID,
+		Root:       unescapeMountInfo(fields[3]),
+		MountPoint: unescapeMountInfo(fields[4]),
+		Options:    fields[5],
+		FSType:     fields[sep+1],
+		Source:     unescapeMountInfo(fields[sep+2]),
+	}
+	if len(fields) > sep+3 {
+		entry.SuperOptions = fields[sep+3]
+	}
+	return entry, nil
+}
+
+// unescapeMountInfo decodes the octal escapes (\040 for space, \011 for tab,
+// \012 for newline, \134 for backslash) the kernel uses in mountinfo paths.
+func unescapeMountInfo(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// FindMountInfo returns the entry mounted exactly at path, or nil if path is not a mountpoint.
+// When several filesystems are stacked on the same mountpoint, the last (topmost) one wins.
+func FindMountInfo(entries []MountInfo, path string) *MountInfo {
+	target := filepath.Clean(path)
+	var found *MountInfo
+	for i := range entries {
+		if entries[i].MountPoint == target {
+			found = &entries[i]
+		}
+	}
+	return found
+}
+
+// mountinfoCheck verifies the mount path is a mountpoint. The verification itself
+// runs before every check that requires it (see CheckSpec.verifiesMountpoint),
+// so there is nothing left to do when the check type runs.
+type mountinfoCheck struct{}
+
+func (mountinfoCheck) Decode(json.RawMessage) (any, error) { return nil, nil }
+
+func (mountinfoCheck) Run(context.Context, string, *CheckSpec) (ProbeResult, error) {
+	return ProbeResult{}, nil
+}
+
+// verifyMountpoint checks that the mount path is a live mountpoint with one of the
+// expected filesystem types. The matching entry is returned even when the fstype
+// check fails, so the caller can report what is actually mounted.
+func verifyMountpoint(mountPath string, spec MountpointSpec) (*MountInfo, error) {
+	path, err := filepath.Abs(mountPath)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(mountInfoPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading mount table: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := ParseMountInfo(f)
+	if err != nil {
+		return nil, err
+	}
+
+	info := FindMountInfo(entries, path)
+	if info == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotMountpoint, path)
+	}
+
+	if len(spec.FSTypes) > 0 {
+		for _, fsType := range spec.FSTypes {
+			if info.FSType == fsType {
+				return info, nil
+			}
+		}
+		return info, fmt.Errorf("%w: %s is mounted as %s, expected one of: %s",
+			ErrUnexpectedFSType, path, info.FSType, strings.Join(spec.FSTypes, ", "))
+	}
+
+	return info, nil
+}
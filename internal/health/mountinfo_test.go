@@ -0,0 +1,151 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/matryer/is"
+)
+
+func parseFixture(t *testing.T, name string) []health.MountInfo {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to open fixture: %v", err)
+	}
+	defer f.Close()
+
+	entries, err := health.ParseMountInfo(f)
+	if err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+	return entries
+}
+
+func TestParseMountInfo(t *testing.T) {
+	is := is.New(t)
+
+	entries := parseFixture(t, "mountinfo-rclone.txt")
+	is.Equal(len(entries), 6) // all entries parsed
+
+	movies := entries[2]
+	is.Equal(movies.MountID, 610)                                        // mount ID
+	is.Equal(movies.ParentID, 22)                                        // parent ID
+	is.Equal(movies.MountPoint, "/mnt/movies")                           // mount point
+	is.Equal(movies.FSType, "fuse.rclone")                               // fstype after separator
+	is.Equal(movies.Source, "debrid:")                                   // source
+	is.Equal(movies.Options, "rw,nosuid,nodev,relatime")                 // per-mount options
+	is.Equal(movies.SuperOptions, "rw,user_id=0,group_id=0,allow_other") // super options
+
+	local := entries[4]
+	is.Equal(local.Root, "/data/local") // bind mount root
+
+	library := entries[5]
+	is.Equal(library.MountPoint, "/mnt/my library") // octal escapes decoded
+	is.Equal(library.FSType, "fuse.rclone")         // multiple optional fields handled
+}
+
+func TestParseMountInfo_Malformed(t *testing.T) {
+	is := is.New(t)
+
+	_, err := health.ParseMountInfo(strings.NewReader("22 1 8:1 / / rw,relatime shared:1 ext4 /dev/sda1 rw\n"))
+	is.True(err != nil) // missing separator should error
+
+	_, err = health.ParseMountInfo(strings.NewReader("x 1 8:1 / / rw - ext4 /dev/sda1 rw\n"))
+	is.True(err != nil) // non-numeric mount ID should error
+}
+
+func TestFindMountInfo(t *testing.T) {
+	is := is.New(t)
+
+	entries := parseFixture(t, "mountinfo-rclone.txt")
+
+	is.True(health.FindMountInfo(entries, "/mnt/tv") != nil)            // exact match
+	is.Equal(health.FindMountInfo(entries, "/mnt/tv/").FSType, "nfs4")  // trailing slash cleaned
+	is.True(health.FindMountInfo(entries, "/mnt") == nil)               // parent directory is not a mountpoint
+	is.True(health.FindMountInfo(entries, "/mnt/movies/subdir") == nil) // subdirectory is not a mountpoint
+
+	stacked := parseFixture(t, "mountinfo-stacked.txt")
+	is.Equal(health.FindMountInfo(stacked, "/mnt/movies").FSType, "fuse.rclone") // topmost mount wins
+}
+
+func TestChecker_MountinfoCheck(t *testing.T) {
+	restore := health.SetMountInfoPathForTesting(filepath.Join("testdata", "mountinfo-rclone.txt"))
+	defer restore()
+
+	tests := []struct {
+		name    string
+		path    string
+		fsTypes []string
+		wantErr error
+	}{
+		{"mounted any fstype", "/mnt/movies", nil, nil},
+		{"mounted expected fstype", "/mnt/movies", []string{"fuse.rclone"}, nil},
+		{"mounted one of fstypes", "/mnt/tv", []string{"fuse.rclone", "nfs4"}, nil},
+		{"unexpected fstype", "/mnt/local", []string{"fuse.rclone"}, health.ErrUnexpectedFSType},
+		{"not a mountpoint", "/mnt/missing", nil, health.ErrNotMountpoint},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			mount := health.NewMountWithCheckType("", tt.path, "", health.CheckTypeMountinfo, 3)
+			mount.Mountpoint = health.MountpointSpec{FSTypes: tt.fsTypes}
+			checker := health.NewChecker(5 * time.Second)
+
+			result := checker.Check(context.Background(), mount)
+
+			if tt.wantErr == nil {
+				is.NoErr(result.Error)           // check should pass
+				is.True(result.MountInfo != nil) // mount table entry reported
+			} else {
+				is.True(errors.Is(result.Error, tt.wantErr)) // expected error
+			}
+		})
+	}
+}
+
+func TestChecker_RequireMountpoint(t *testing.T) {
+	is := is.New(t)
+
+	// The temp dir is not a mountpoint in the fixture, so the directory check never runs
+	restore := health.SetMountInfoPathForTesting(filepath.Join("testdata", "mountinfo-rclone.txt"))
+	defer restore()
+
+	tmpDir := t.TempDir()
+	mount := health.NewMountWithCheckType("", tmpDir, "", health.CheckTypeDirectory, 1)
+	mount.Mountpoint = health.MountpointSpec{Require: true}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                                  // unmounted path should fail
+	is.True(errors.Is(result.Error, health.ErrNotMountpoint)) // mountpoint error
+
+	mount.UpdateState(result, 1)
+	is.Equal(mount.Snapshot().FSType, "") // no mount table entry to report
+}
+
+func TestMount_SnapshotIncludesMountInfo(t *testing.T) {
+	is := is.New(t)
+
+	restore := health.SetMountInfoPathForTesting(filepath.Join("testdata", "mountinfo-rclone.txt"))
+	defer restore()
+
+	mount := health.NewMountWithCheckType("movies", "/mnt/movies", "", health.CheckTypeMountinfo, 3)
+	checker := health.NewChecker(5 * time.Second)
+
+	mount.UpdateState(checker.Check(context.Background(), mount), 3)
+	snapshot := mount.Snapshot()
+
+	is.Equal(snapshot.MountSource, "debrid:")                   // source reported
+	is.Equal(snapshot.FSType, "fuse.rclone")                    // fstype reported
+	is.Equal(snapshot.MountOptions, "rw,nosuid,nodev,relatime") // options reported
+}
@@ -0,0 +1,137 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+)
+
+// DefaultRangeBytes is the number of bytes a range-read check reads when not configured.
+// 64KiB is large enough to force a fetch from the debrid backend on a cache miss,
+// but small enough to complete well within a typical read timeout.
+const DefaultRangeBytes int64 = 64 << 10
+
+// ErrNoRangeReadCandidate indicates no regular, non-empty file matched the range-read glob.
+var ErrNoRangeReadCandidate = errors.New("no file matches range-read glob")
+
+// rangeReadCheck reads a chunk of a large file at a random offset.
+type rangeReadCheck struct{}
+
+// Decode requires exactly one of rangeFile or rangeGlob.
+func (rangeReadCheck) Decode(options json.RawMessage) (any, error) {
+	r, err := decodeOptions[RangeReadSpec](options)
+	if err != nil {
+		return nil, err
+	}
+	if (r.File == "") == (r.Glob == "") {
+		return nil, fmt.Errorf("range-read check requires exactly one of rangeFile or rangeGlob")
+	}
+	if r.File != "" {
+		if err := validateMountRelativePath("rangeFile", r.File); err != nil {
+			return nil, err
+		}
+	}
+	if r.Glob != "" {
+		if err := validateMountRelativePath("rangeGlob", r.Glob); err != nil {
+			return nil, err
+		}
+		if _, err := filepath.Match(r.Glob, ""); err != nil {
+			return nil, fmt.Errorf("rangeGlob %q is not a valid pattern: %w", r.Glob, err)
+		}
+	}
+	if r.Bytes < 0 {
+		return nil, fmt.Errorf("rangeBytes must be >= 0, got %d", r.Bytes)
+	}
+	return r, nil
+}
+
+func (rangeReadCheck) Run(_ context.Context, path string, spec *CheckSpec) (ProbeResult, error) {
+	bytesRead, err := checkRangeRead(path, settingsOf[RangeReadSpec](spec))
+	return ProbeResult{BytesRead: bytesRead}, err
+}
+
+// checkRangeRead reads Bytes bytes from a random offset of the configured (or a random
+// glob-matched) file under the mount and returns the number of bytes read.
+//
+// Unlike the canary check, this exercises the streaming path to the debrid backend:
+// large media files are rarely fully cached, so a random offset forces a real fetch.
+func checkRangeRead(mountPath string, spec RangeReadSpec) (int64, error) {
+	path, err := selectRangeReadFile(mountPath, spec)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if !info.Mode().IsRegular() {
+		return 0, fmt.Errorf("range-read target is not a regular file: %s", path)
+	}
+
+	want := spec.Bytes
+	if want <= 0 {
+		want = DefaultRangeBytes
+	}
+	size := info.Size()
+	if size < want {
+		want = size
+	}
+	if want == 0 {
+		return 0, fmt.Errorf("range-read target is empty: %s", path)
+	}
+
+	var offset int64
+	if size > want {
+		offset = rand.Int63n(size - want + 1)
+	}
+
+	buf := make([]byte, want)
+	n, err := f.ReadAt(buf, offset)
+	if err != nil && !(errors.Is(err, io.EOF) && int64(n) == want) {
+		return int64(n), fmt.Errorf("reading %d bytes at offset %d of %s: %w", want, offset, path, err)
+	}
+	if int64(n) != want {
+		return int64(n), fmt.Errorf("short read at offset %d of %s: got %d of %d bytes", offset, path, n, want)
+	}
+
+	return int64(n), nil
+}
+
+// selectRangeReadFile returns the file a range-read check should read.
+func selectRangeReadFile(mountPath string, spec RangeReadSpec) (string, error) {
+	if spec.File != "" {
+		return filepath.Join(mountPath, spec.File), nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(mountPath, spec.Glob))
+	if err != nil {
+		return "", err
+	}
+
+	// Start at a random match and take the first usable one, skipping
+	// directories and empty files.
+	if len(matches) > 0 {
+		start := rand.Intn(len(matches))
+		for i := range matches {
+			candidate := matches[(start+i)%len(matches)]
+			info, err := os.Stat(candidate)
+			if err == nil && info.Mode().IsRegular() && info.Size() > 0 {
+				return candidate, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("%w %q under %s", ErrNoRangeReadCandidate, spec.Glob, mountPath)
+}
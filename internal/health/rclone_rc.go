@@ -0,0 +1,126 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/rclone"
+)
+
+var (
+	// ErrRcloneMountMissing indicates rclone's rc server does not list the mount
+	// in mount/listmounts, e.g. because the mount was dropped after a crash.
+	ErrRcloneMountMissing = errors.New("rclone mount missing")
+
+	// ErrRcloneUploadQueue indicates more VFS cache uploads are queued than allowed.
+	ErrRcloneUploadQueue = errors.New("rclone upload queue exceeded")
+)
+
+// RcloneSpec describes the rclone remote control (rc) endpoint an rclone-rc check queries.
+type RcloneSpec struct {
+	URL            string `json:"rcUrl"`          // rc server URL, e.g. "http://localhost:5572"
+	Username       string `json:"rcUsername"`     // rc basic auth username (optional, rclone's --rc-user)
+	Password       string `json:"rcPassword"`     // rc basic auth password (optional, rclone's --rc-pass)
+	MountPoint     string `json:"rcMountPoint"`   // Mount point as rclone sees it (empty = mount path)
+	MaxUploadQueue int    `json:"maxUploadQueue"` // Maximum queued VFS cache uploads (0 = no limit)
+}
+
+// RcloneStats holds the rclone statistics gathered by an rclone-rc check.
+// Cache fields are zero when the VFS cache is disabled; transfer and error
+// counts are for the whole rclone instance.
+type RcloneStats struct {
+	Remote            string // Remote served by the mount, e.g. "debrid:"
+	CacheBytes        int64  // Bytes used by the VFS disk cache
+	CacheFiles        int64  // Files in the VFS disk cache
+	UploadsInProgress int    // VFS cache uploads in progress
+	UploadsQueued     int    // VFS cache uploads waiting to start
+	ErroredFiles      int    // VFS cache files that failed to upload
+	Transfers         int    // Transfers in flight
+	Errors            int64  // Errors since rclone started (or its stats were reset)
+	LastError         string // Most recent error reported by rclone
+}
+
+// rcloneRCCheck asks rclone, through its rc API, whether it is still serving the mount.
+type rcloneRCCheck struct{}
+
+func (rcloneRCCheck) Decode(options json.RawMessage) (any, error) {
+	r, err := decodeOptions[RcloneSpec](options)
+	if err != nil {
+		return nil, err
+	}
+	if r.URL == "" {
+		return nil, fmt.Errorf("rclone-rc check requires rcUrl")
+	}
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return nil, fmt.Errorf("rcUrl is not valid: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("rcUrl must be an absolute http or https URL, got %q", u.Redacted())
+	}
+	if r.Password != "" && r.Username == "" {
+		return nil, fmt.Errorf("rcPassword requires rcUsername")
+	}
+	if r.MaxUploadQueue < 0 {
+		return nil, fmt.Errorf("maxUploadQueue must be >= 0, got %d", r.MaxUploadQueue)
+	}
+	return r, nil
+}
+
+// Run looks the mount up in mount/listmounts, then gathers its VFS and transfer
+// statistics. Stats are returned alongside an upload queue error, so the status
+// endpoint shows what the queue looked like.
+func (rcloneRCCheck) Run(ctx context.Context, mountPath string, spec *CheckSpec) (ProbeResult, error) {
+	r := settingsOf[RcloneSpec](spec)
+	client := rclone.NewClient(r.URL, r.Username, r.Password)
+
+	mountPoint := r.MountPoint
+	if mountPoint == "" {
+		mountPoint = mountPath
+	}
+
+	mounts, err := client.ListMounts(ctx)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+	mount := rclone.FindMount(mounts, mountPoint)
+	if mount == nil {
+		return ProbeResult{}, fmt.Errorf("%w: %s is not listed by %s", ErrRcloneMountMissing, mountPoint, redactURL(r.URL))
+	}
+
+	vfs, err := client.VFSStats(ctx, mount.Fs)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+	core, err := client.CoreStats(ctx)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+
+	stats := &RcloneStats{
+		Remote:    mount.Fs,
+		Transfers: len(core.Transferring),
+		Errors:    core.Errors,
+		LastError: core.LastError,
+	}
+	if dc := vfs.DiskCache; dc != nil {
+		stats.CacheBytes = dc.BytesUsed
+		stats.CacheFiles = dc.Files
+		stats.UploadsInProgress = dc.UploadsInProgress
+		stats.UploadsQueued = dc.UploadsQueued
+		stats.ErroredFiles = dc.ErroredFiles
+	}
+
+	if r.MaxUploadQueue > 0 && stats.UploadsQueued > r.MaxUploadQueue {
+		return ProbeResult{Rclone: stats}, fmt.Errorf("%w: %d uploads queued, maximum is %d", ErrRcloneUploadQueue, stats.UploadsQueued, r.MaxUploadQueue)
+	}
+	return ProbeResult{Rclone: stats}, nil
+}
+
+// Dependency identifies the rclone instance by its rc URL, without credentials.
+func (rcloneRCCheck) Dependency(spec *CheckSpec) string {
+	return redactURL(settingsOf[RcloneSpec](spec).URL)
+}
@@ -0,0 +1,141 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/cscheib/debrid-mount-monitor/internal/testutil"
+	"github.com/matryer/is"
+)
+
+// newRcloneRC returns a fake rc server serving a single mount of "debrid:" at mountPoint.
+func newRcloneRC(t *testing.T, mountPoint string, uploadsQueued int) *testutil.FakeRC {
+	t.Helper()
+	rc := testutil.NewFakeRC(t)
+	rc.SetResponse("mount/listmounts", map[string]any{
+		"mountPoints": []map[string]any{{"Fs": "debrid:", "MountPoint": mountPoint}},
+	})
+	rc.SetResponse("vfs/stats", map[string]any{
+		"fs": "debrid:",
+		"diskCache": map[string]any{
+			"bytesUsed":         2048,
+			"files":             4,
+			"uploadsInProgress": 1,
+			"uploadsQueued":     uploadsQueued,
+		},
+	})
+	rc.SetResponse("core/stats", map[string]any{
+		"errors":       3,
+		"lastError":    "couldn't fetch token",
+		"transferring": []map[string]any{{"name": "movie.mkv"}, {"name": "show.mkv"}},
+	})
+	return rc
+}
+
+func TestChecker_RcloneRCCheck(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	rc := newRcloneRC(t, tmpDir, 2)
+
+	mount := health.NewMountWithCheckType("", tmpDir, "", health.CheckTypeRcloneRC, 3)
+	mount.Options = checkOptions(t, health.RcloneSpec{URL: rc.URL, MaxUploadQueue: 5})
+	is.NoErr(health.DecodeCheckSpec(&mount.CheckSpec)) // spec should be valid
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(result.Success)                                   // listed mount should pass
+	is.True(result.Rclone != nil)                             // stats should be gathered
+	is.Equal(result.Rclone.Remote, "debrid:")                 // remote from listmounts
+	is.Equal(result.Rclone.CacheBytes, int64(2048))           // cache size
+	is.Equal(result.Rclone.UploadsQueued, 2)                  // queued uploads
+	is.Equal(result.Rclone.Transfers, 2)                      // in-flight transfers
+	is.Equal(result.Rclone.Errors, int64(3))                  // error count
+	is.Equal(result.Rclone.LastError, "couldn't fetch token") // last error
+	is.Equal(rc.Calls()[1].Params["fs"], "debrid:")           // vfs/stats selects the mount's remote
+	is.Equal(len(result.Dependencies), 1)                     // rc endpoint is a dependency
+	is.Equal(result.Dependencies[0].Target, rc.URL)           // dependency target
+}
+
+func TestChecker_RcloneRCCheck_Failures(t *testing.T) {
+	tests := []struct {
+		name       string
+		mountPoint string // rcMountPoint override ("" = mount path)
+		queued     int
+		maxQueue   int
+		wantErr    error
+		wantStats  bool
+	}{
+		{name: "mount missing", mountPoint: "/mnt/elsewhere", wantErr: health.ErrRcloneMountMissing},
+		{name: "upload queue exceeded", queued: 10, maxQueue: 5, wantErr: health.ErrRcloneUploadQueue, wantStats: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			tmpDir := t.TempDir()
+			rc := newRcloneRC(t, tmpDir, tt.queued)
+
+			mount := health.NewMountWithCheckType("", tmpDir, "", health.CheckTypeRcloneRC, 3)
+			mount.Settings = health.RcloneSpec{URL: rc.URL, MountPoint: tt.mountPoint, MaxUploadQueue: tt.maxQueue}
+			checker := health.NewChecker(5 * time.Second)
+
+			result := checker.Check(context.Background(), mount)
+
+			is.True(!result.Success)                     // check should fail
+			is.True(errors.Is(result.Error, tt.wantErr)) // failure cause
+			is.Equal(result.Rclone != nil, tt.wantStats) // stats reported when gathered
+		})
+	}
+}
+
+func TestChecker_RcloneRCCheck_RCError(t *testing.T) {
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	rc := newRcloneRC(t, tmpDir, 0)
+	rc.SetError("vfs/stats", http.StatusInternalServerError, "no VFS found")
+
+	mount := health.NewMountWithCheckType("", tmpDir, "", health.CheckTypeRcloneRC, 3)
+	mount.Settings = health.RcloneSpec{URL: rc.URL}
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success)                            // rc error should fail the check
+	is.True(result.Error != nil)                        // error reported
+	is.Equal(result.Dependencies[0].Status(), "failed") // rclone dependency is down
+}
+
+func TestRcloneRCCheck_Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    health.RcloneSpec
+		wantErr bool
+	}{
+		{name: "valid", spec: health.RcloneSpec{URL: "http://localhost:5572"}},
+		{name: "valid with auth", spec: health.RcloneSpec{URL: "http://localhost:5572", Username: "rc", Password: "secret"}},
+		{name: "missing url", spec: health.RcloneSpec{}, wantErr: true},
+		{name: "relative url", spec: health.RcloneSpec{URL: "localhost:5572"}, wantErr: true},
+		{name: "password without username", spec: health.RcloneSpec{URL: "http://localhost:5572", Password: "secret"}, wantErr: true},
+		{name: "negative max upload queue", spec: health.RcloneSpec{URL: "http://localhost:5572", MaxUploadQueue: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			spec := health.NewCheckSpec("/mnt/debrid", "", health.CheckTypeRcloneRC)
+			spec.Options = checkOptions(t, tt.spec)
+
+			err := health.DecodeCheckSpec(&spec)
+			is.Equal(err != nil, tt.wantErr) // validation outcome
+		})
+	}
+}
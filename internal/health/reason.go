@@ -0,0 +1,159 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"syscall"
+)
+
+// FailureReason classifies why a check failed. It distinguishes failures that
+// call for different handling, such as a dead FUSE mount (ENOTCONN) versus a
+// slow backend (timeout).
+type FailureReason int
+
+const (
+	// ReasonNone indicates the check passed.
+	ReasonNone FailureReason = iota
+	// ReasonOther is any failure not covered by a more specific reason.
+	ReasonOther
+	// ReasonTimeout indicates the check did not complete within the read timeout.
+	ReasonTimeout
+	// ReasonCanceled indicates the check was cancelled (e.g. during shutdown).
+	ReasonCanceled
+	// ReasonNotConnected indicates ENOTCONN, typically a FUSE mount whose daemon exited.
+	ReasonNotConnected
+	// ReasonStale indicates ESTALE, a stale file handle.
+	ReasonStale
+	// ReasonIOError indicates EIO, an I/O error reported by the filesystem.
+	ReasonIOError
+	// ReasonNotFound indicates ENOENT, a missing file or directory.
+	ReasonNotFound
+	// ReasonPermission indicates EACCES or EPERM.
+	ReasonPermission
+	// ReasonContentMismatch indicates unexpected file or response content (ErrContentMismatch).
+	ReasonContentMismatch
+	// ReasonListingMismatch indicates unexpected directory contents (ErrListingMismatch).
+	ReasonListingMismatch
+	// ReasonNotMountpoint indicates the path is not a mountpoint (ErrNotMountpoint).
+	ReasonNotMountpoint
+	// ReasonUnexpectedFSType indicates an unexpected filesystem type (ErrUnexpectedFSType).
+	ReasonUnexpectedFSType
+	// ReasonExecFailed indicates an exec check command exited unsuccessfully (ErrExecFailed).
+	ReasonExecFailed
+	// ReasonHTTPStatus indicates an unexpected HTTP status (ErrUnexpectedHTTPStatus).
+	ReasonHTTPStatus
+	// ReasonRcloneMountMissing indicates rclone does not list the mount (ErrRcloneMountMissing).
+	ReasonRcloneMountMissing
+	// ReasonRcloneUploadQueue indicates too many queued rclone uploads (ErrRcloneUploadQueue).
+	ReasonRcloneUploadQueue
+	// ReasonSlow indicates a check exceeded the mount's latency fail threshold (ErrSlowCheck).
+	ReasonSlow
+	// ReasonProbeHung indicates a check was skipped because an earlier probe is still blocked (ErrProbeHung).
+	ReasonProbeHung
+)
+
+// failureReasonNames maps reasons to their names, which are used in status
+// output, logs and metric labels.
+var failureReasonNames = map[FailureReason]string{
+	ReasonNone:               "none",
+	ReasonOther:              "other",
+	ReasonTimeout:            "timeout",
+	ReasonCanceled:           "canceled",
+	ReasonNotConnected:       "not_connected",
+	ReasonStale:              "stale",
+	ReasonIOError:            "io_error",
+	ReasonNotFound:           "not_found",
+	ReasonPermission:         "permission",
+	ReasonContentMismatch:    "content_mismatch",
+	ReasonListingMismatch:    "listing_mismatch",
+	ReasonNotMountpoint:      "not_mountpoint",
+	ReasonUnexpectedFSType:   "unexpected_fstype",
+	ReasonExecFailed:         "exec_failed",
+	ReasonHTTPStatus:         "http_status",
+	ReasonRcloneMountMissing: "rclone_mount_missing",
+	ReasonRcloneUploadQueue:  "rclone_upload_queue",
+	ReasonSlow:               "slow",
+	ReasonProbeHung:          "probe_hung",
+}
+
+// String returns the name of the failure reason, e.g. "not_connected".
+func (r FailureReason) String() string {
+	if name, ok := failureReasonNames[r]; ok {
+		return name
+	}
+	return "other"
+}
+
+// ParseFailureReason returns the failure reason with the given name.
+func ParseFailureReason(name string) (FailureReason, bool) {
+	for r, n := range failureReasonNames {
+		if n == name {
+			return r, true
+		}
+	}
+	return ReasonOther, false
+}
+
+// FailureReasonNames returns the names of all failure reasons except "none", in
+// declaration order.
+func FailureReasonNames() []string {
+	names := make([]string, 0, len(failureReasonNames)-1)
+	for r := ReasonOther; r <= ReasonProbeHung; r++ {
+		names = append(names, r.String())
+	}
+	return names
+}
+
+// ClassifyFailure returns the reason for a check error. Errno values are matched
+// before the generic fs errors, so ENOTCONN is not reported as "other" and EIO is
+// not hidden behind a wrapping sentinel. It returns ReasonNone for a nil error.
+func ClassifyFailure(err error) FailureReason {
+	var errno syscall.Errno
+	switch {
+	case err == nil:
+		return ReasonNone
+	case errors.Is(err, context.DeadlineExceeded):
+		return ReasonTimeout
+	case errors.Is(err, context.Canceled):
+		return ReasonCanceled
+	case errors.As(err, &errno) && errnoReasons[errno] != ReasonNone:
+		return errnoReasons[errno]
+	case errors.Is(err, fs.ErrNotExist):
+		return ReasonNotFound
+	case errors.Is(err, fs.ErrPermission):
+		return ReasonPermission
+	case errors.Is(err, ErrContentMismatch):
+		return ReasonContentMismatch
+	case errors.Is(err, ErrListingMismatch):
+		return ReasonListingMismatch
+	case errors.Is(err, ErrNotMountpoint):
+		return ReasonNotMountpoint
+	case errors.Is(err, ErrUnexpectedFSType):
+		return ReasonUnexpectedFSType
+	case errors.Is(err, ErrExecFailed):
+		return ReasonExecFailed
+	case errors.Is(err, ErrUnexpectedHTTPStatus):
+		return ReasonHTTPStatus
+	case errors.Is(err, ErrRcloneMountMissing):
+		return ReasonRcloneMountMissing
+	case errors.Is(err, ErrRcloneUploadQueue):
+		return ReasonRcloneUploadQueue
+	case errors.Is(err, ErrSlowCheck):
+		return ReasonSlow
+	case errors.Is(err, ErrProbeHung):
+		return ReasonProbeHung
+	default:
+		return ReasonOther
+	}
+}
+
+// errnoReasons maps errno values to failure reasons.
+var errnoReasons = map[syscall.Errno]FailureReason{
+	syscall.ENOTCONN: ReasonNotConnected,
+	syscall.ESTALE:   ReasonStale,
+	syscall.EIO:      ReasonIOError,
+	syscall.ENOENT:   ReasonNotFound,
+	syscall.EACCES:   ReasonPermission,
+	syscall.EPERM:    ReasonPermission,
+}
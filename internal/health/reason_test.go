@@ -0,0 +1,90 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/matryer/is"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want health.FailureReason
+	}{
+		{"nil", nil, health.ReasonNone},
+		{"timeout", fmt.Errorf("read: %w", context.DeadlineExceeded), health.ReasonTimeout},
+		{"canceled", context.Canceled, health.ReasonCanceled},
+		{"enotconn", &fs.PathError{Op: "open", Path: "/mnt/test", Err: syscall.ENOTCONN}, health.ReasonNotConnected},
+		{"estale", &fs.PathError{Op: "stat", Path: "/mnt/test", Err: syscall.ESTALE}, health.ReasonStale},
+		{"eio", &fs.PathError{Op: "read", Path: "/mnt/test", Err: syscall.EIO}, health.ReasonIOError},
+		{"enoent", &fs.PathError{Op: "open", Path: "/mnt/test", Err: syscall.ENOENT}, health.ReasonNotFound},
+		{"eacces", &fs.PathError{Op: "open", Path: "/mnt/test", Err: syscall.EACCES}, health.ReasonPermission},
+		{"not exist", fmt.Errorf("open: %w", fs.ErrNotExist), health.ReasonNotFound},
+		{"content mismatch", fmt.Errorf("%w: got %q", health.ErrContentMismatch, "x"), health.ReasonContentMismatch},
+		{"not mountpoint", fmt.Errorf("%w: /mnt/test", health.ErrNotMountpoint), health.ReasonNotMountpoint},
+		{"other", errors.New("boom"), health.ReasonOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(health.ClassifyFailure(tt.err), tt.want)
+		})
+	}
+}
+
+func TestFailureReason_String(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(health.ReasonNotConnected.String(), "not_connected") // name
+	is.Equal(health.FailureReason(999).String(), "other")         // unknown reason
+
+	reason, ok := health.ParseFailureReason("stale")
+	is.True(ok)                          // known name
+	is.Equal(reason, health.ReasonStale) // parsed reason
+
+	_, ok = health.ParseFailureReason("bogus")
+	is.True(!ok) // unknown name
+}
+
+func TestMount_UpdateState_FailurePolicy(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 3)
+	mount.FailurePolicies = map[health.FailureReason]health.FailurePolicy{
+		health.ReasonNotConnected: {Threshold: 1},
+	}
+
+	// Timeouts count toward the mount's threshold as usual
+	mount.UpdateState(&health.CheckResult{
+		Mount:     mount,
+		Timestamp: time.Now(),
+		Error:     context.DeadlineExceeded,
+	}, 3)
+	is.Equal(mount.GetStatus(), health.StatusDegraded)          // timeout counts normally
+	is.Equal(mount.Snapshot().LastReason, health.ReasonTimeout) // reason recorded
+
+	// ENOTCONN marks the mount unhealthy immediately
+	transition := mount.UpdateState(&health.CheckResult{
+		Mount:     mount,
+		Timestamp: time.Now(),
+		Error:     &fs.PathError{Op: "open", Path: "/mnt/test", Err: syscall.ENOTCONN},
+	}, 3)
+	is.True(transition != nil)                                       // degraded -> unhealthy
+	is.Equal(transition.Trigger, "rule:not_connected")               // matched rule recorded as trigger
+	is.True(!transition.SkipWatchdog)                                // rule does not skip the watchdog
+	is.Equal(mount.GetStatus(), health.StatusUnhealthy)              // unhealthy without reaching threshold
+	is.Equal(mount.Snapshot().LastReason, health.ReasonNotConnected) // reason recorded
+
+	// Recovery clears the reason
+	mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: true}, 3)
+	is.Equal(mount.Snapshot().LastReason, health.ReasonNone) // cleared on success
+}
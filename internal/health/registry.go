@@ -0,0 +1,144 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// CheckProvider implements a check type. Providers are registered under their
+// check type name with RegisterCheckType and selected by a mount's checkType.
+//
+// Built-in check types are registered by this package. Other packages can add
+// their own check types by registering a provider from an init function.
+//
+// Each provider owns the settings of its check type: they are fields of the JSON
+// object that configures the check, which the provider decodes itself.
+type CheckProvider interface {
+	// Decode decodes and validates the type-specific settings of a check from
+	// options, the JSON object configuring the check (a mount, or an entry of its
+	// checks). The object also holds settings of the mount and of other check types,
+	// which Decode ignores. options may be empty if the check has no settings.
+	// Errors should refer to settings by their config file field names.
+	//
+	// The returned value is stored in CheckSpec.Settings for Run.
+	Decode(options json.RawMessage) (any, error)
+
+	// Run performs the check against the mount at mountPath. It returns what the
+	// check observed besides its outcome, which may be set even if the check failed.
+	//
+	// ctx is cancelled when the read timeout expires. Run may still block on hung
+	// mount I/O, since file reads cannot be cancelled, but should release any other
+	// resources (such as child processes) once ctx is done.
+	Run(ctx context.Context, mountPath string, spec *CheckSpec) (ProbeResult, error)
+}
+
+// ProbeResult holds the data a check provider gathered while running a check.
+type ProbeResult struct {
+	BytesRead int64        // Bytes read, for throughput reporting (0 for checks that don't measure reads)
+	Rclone    *RcloneStats // rclone statistics for the status endpoint (nil if not gathered)
+}
+
+// DependencyProvider is implemented by check providers that probe a dependency
+// of the mount, such as the WebDAV server behind it, rather than the mount itself.
+// Results of such checks are also reported per dependency, so a failing mount can
+// be attributed to the FUSE layer or to its backend.
+type DependencyProvider interface {
+	// Dependency returns a human-readable identifier of the probed dependency
+	// (e.g. its URL). It must not contain credentials.
+	Dependency(spec *CheckSpec) string
+}
+
+// registry holds the registered check providers.
+var registry = struct {
+	sync.RWMutex
+	providers map[string]CheckProvider
+	names     []string // Registration order, for stable error messages
+}{
+	providers: make(map[string]CheckProvider),
+}
+
+// RegisterCheckType makes a check provider available under the given check type name.
+// It panics if name is empty, provider is nil, or name is already registered.
+func RegisterCheckType(name string, provider CheckProvider) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if name == "" {
+		panic("health: RegisterCheckType with empty name")
+	}
+	if provider == nil {
+		panic("health: RegisterCheckType provider is nil for " + name)
+	}
+	if _, dup := registry.providers[name]; dup {
+		panic("health: RegisterCheckType called twice for " + name)
+	}
+	registry.providers[name] = provider
+	registry.names = append(registry.names, name)
+}
+
+// LookupCheckType returns the provider registered for a check type name.
+func LookupCheckType(name string) (CheckProvider, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	p, ok := registry.providers[name]
+	return p, ok
+}
+
+// CheckTypes returns the registered check type names in registration order.
+func CheckTypes() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, len(registry.names))
+	copy(names, registry.names)
+	return names
+}
+
+// DecodeCheckSpec decodes a check's type-specific settings from spec.Options
+// with its registered provider and stores them in spec.Settings.
+func DecodeCheckSpec(spec *CheckSpec) error {
+	provider, ok := LookupCheckType(spec.CheckType)
+	if !ok {
+		return fmt.Errorf("unsupported check type %q", spec.CheckType)
+	}
+	settings, err := provider.Decode(spec.Options)
+	if err != nil {
+		return err
+	}
+	spec.Settings = settings
+	return nil
+}
+
+// decodeOptions decodes the fields of options into the settings type of a check type.
+// Empty options decode to the zero value.
+func decodeOptions[T any](options json.RawMessage) (T, error) {
+	var settings T
+	if len(options) == 0 {
+		return settings, nil
+	}
+	if err := json.Unmarshal(options, &settings); err != nil {
+		return settings, err
+	}
+	return settings, nil
+}
+
+// settingsOf returns the decoded settings of spec, or the zero value if spec
+// holds no settings of type T.
+func settingsOf[T any](spec *CheckSpec) T {
+	settings, _ := spec.Settings.(T)
+	return settings
+}
+
+func init() {
+	RegisterCheckType(CheckTypeCanary, canaryCheck{})
+	RegisterCheckType(CheckTypeDirectory, directoryCheck{})
+	RegisterCheckType(CheckTypeContent, contentCheck{})
+	RegisterCheckType(CheckTypeWrite, writeCheck{})
+	RegisterCheckType(CheckTypeRangeRead, rangeReadCheck{})
+	RegisterCheckType(CheckTypeListing, listingCheck{})
+	RegisterCheckType(CheckTypeMountinfo, mountinfoCheck{})
+	RegisterCheckType(CheckTypeExec, execCheck{})
+	RegisterCheckType(CheckTypeHTTP, httpCheck{})
+	RegisterCheckType(CheckTypeRcloneRC, rcloneRCCheck{})
+}
@@ -0,0 +1,114 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/matryer/is"
+)
+
+// flagFileCheck is a custom check type that passes or fails based on its "pass" setting.
+type flagFileCheck struct{}
+
+type flagFileOptions struct {
+	Pass *bool `json:"pass"`
+}
+
+func (flagFileCheck) Decode(options json.RawMessage) (any, error) {
+	var opts flagFileOptions
+	if len(options) > 0 {
+		if err := json.Unmarshal(options, &opts); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Pass == nil {
+		return nil, errors.New("pass is required")
+	}
+	return opts, nil
+}
+
+func (flagFileCheck) Run(_ context.Context, _ string, spec *health.CheckSpec) (health.ProbeResult, error) {
+	if !*spec.Settings.(flagFileOptions).Pass {
+		return health.ProbeResult{}, errors.New("flag says fail")
+	}
+	return health.ProbeResult{}, nil
+}
+
+func TestRegistry_BuiltinCheckTypes(t *testing.T) {
+	is := is.New(t)
+
+	for _, name := range []string{
+		health.CheckTypeCanary, health.CheckTypeDirectory, health.CheckTypeContent, health.CheckTypeWrite,
+		health.CheckTypeRangeRead, health.CheckTypeListing, health.CheckTypeMountinfo,
+	} {
+		_, ok := health.LookupCheckType(name)
+		is.True(ok) // built-in check type should be registered
+	}
+
+	_, ok := health.LookupCheckType("bogus")
+	is.True(!ok) // unknown check type should not be registered
+}
+
+func TestRegistry_CustomCheckType(t *testing.T) {
+	is := is.New(t)
+
+	health.RegisterCheckType("test-flag", flagFileCheck{})
+	is.True(contains(health.CheckTypes(), "test-flag")) // custom type is listed
+
+	spec := health.NewCheckSpec(t.TempDir(), "", "test-flag")
+	is.True(health.DecodeCheckSpec(&spec) != nil) // missing setting should fail decoding
+
+	checker := health.NewChecker(5 * time.Second)
+	for _, pass := range []bool{true, false} {
+		mount := health.NewMountWithCheckType("", t.TempDir(), "", "test-flag", 3)
+		mount.Options = json.RawMessage(`{"checkType": "test-flag", "pass": ` + strconv.FormatBool(pass) + `}`)
+		is.NoErr(health.DecodeCheckSpec(&mount.CheckSpec)) // valid settings
+
+		result := checker.Check(context.Background(), mount)
+		is.Equal(result.Success, pass) // custom provider decides the outcome
+	}
+}
+
+func TestRegistry_DuplicateRegistrationPanics(t *testing.T) {
+	is := is.New(t)
+
+	defer func() {
+		is.True(recover() != nil) // registering a built-in name again should panic
+	}()
+	health.RegisterCheckType(health.CheckTypeCanary, flagFileCheck{})
+}
+
+func TestRegistry_UnsupportedCheckType(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", "not-registered", 3)
+	checker := health.NewChecker(5 * time.Second)
+
+	result := checker.Check(context.Background(), mount)
+
+	is.True(!result.Success) // unregistered check type should fail
+}
+
+// checkOptions returns settings encoded as the JSON object configuring a check.
+func checkOptions(t *testing.T, settings any) json.RawMessage {
+	t.Helper()
+	options, err := json.Marshal(settings)
+	if err != nil {
+		t.Fatalf("failed to encode check settings: %v", err)
+	}
+	return options
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
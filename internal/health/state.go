@@ -2,6 +2,7 @@
 package health
 
 import (
+	"encoding/json"
 	"path/filepath"
 	"sync"
 	"time"
@@ -15,17 +16,37 @@ const (
 	StatusUnknown HealthStatus = iota
 	// StatusHealthy indicates the mount is accessible.
 	StatusHealthy
-	// StatusDegraded indicates the mount has failed but is within failure threshold.
+	// StatusDegraded indicates the mount has failed but is within failure threshold,
+	// or its last check passed but was slow (see LatencyPolicy).
 	StatusDegraded
 	// StatusUnhealthy indicates the mount has failed past failure threshold.
 	StatusUnhealthy
+	// StatusRecovering indicates a failing mount has passed checks again, but
+	// fewer than its recovery threshold in a row.
+	StatusRecovering
 )
 
 const (
 	CheckTypeCanary    = "canary"
 	CheckTypeDirectory = "directory"
+	CheckTypeContent   = "content"
+	CheckTypeWrite     = "write"
+	CheckTypeRangeRead = "range-read"
+	CheckTypeListing   = "listing"
+	CheckTypeMountinfo = "mountinfo"
+	CheckTypeExec      = "exec"
+	CheckTypeHTTP      = "http"
+	CheckTypeRcloneRC  = "rclone-rc"
 )
 
+// ContentExpectation describes what a content check expects to read from the canary file.
+// Zero values disable the corresponding comparison.
+type ContentExpectation struct {
+	SHA256  string `json:"expectedSha256"`  // Expected hex-encoded SHA-256 digest (case-insensitive)
+	Exact   string `json:"expectedContent"` // Expected content; trailing CR/LF characters are ignored on both sides
+	MinSize int64  `json:"minSize"`         // Minimum file size in bytes
+}
+
 // String returns the string representation of the health status.
 func (s HealthStatus) String() string {
 	switch s {
@@ -37,23 +58,128 @@ func (s HealthStatus) String() string {
 		return "degraded"
 	case StatusUnhealthy:
 		return "unhealthy"
+	case StatusRecovering:
+		return "recovering"
 	default:
 		return "unknown"
 	}
 }
 
+// RangeReadSpec describes which file a range-read check reads and how much.
+// Exactly one of File or Glob should be set; both are relative to the mount path.
+type RangeReadSpec struct {
+	File  string `json:"rangeFile"`  // Fixed file to read
+	Glob  string `json:"rangeGlob"`  // Glob pattern; a random regular file matching it is read each check
+	Bytes int64  `json:"rangeBytes"` // Bytes to read from a random offset (0 = DefaultRangeBytes)
+}
+
+// ListingSpec describes what a listing check expects to find directly under the mount path.
+type ListingSpec struct {
+	MinEntries   int      `json:"minEntries"`   // Minimum number of entries (0 = 1, i.e. the mount must not be empty)
+	ExpectedDirs []string `json:"expectedDirs"` // Subdirectory names that must be present (trailing "/" is optional)
+}
+
+// MountpointSpec describes how a mount's presence in /proc/self/mountinfo is verified.
+type MountpointSpec struct {
+	Require bool     // Verify the path is a mountpoint before running the check type
+	FSTypes []string // Accepted filesystem types (e.g. "fuse.rclone", "nfs4"); empty accepts any
+}
+
+// Composite check modes.
+const (
+	CheckModeAll = "all" // Healthy only if every check passes
+	CheckModeAny = "any" // Healthy if at least one check passes
+)
+
+// CheckSpec describes a single health check to run against a mount: its type,
+// the settings common to all check types, and the type-specific settings
+// decoded by the check type's provider (see DecodeCheckSpec).
+type CheckSpec struct {
+	CheckType  string          // Type of health check to perform
+	CanaryPath string          // Full path to canary file (canary and content checks)
+	Mountpoint MountpointSpec  // Mountpoint verification (mountinfo check or any check with Require)
+	Options    json.RawMessage // JSON object configuring the check, decoded by its provider
+	Settings   any             `json:"-"` // Type-specific settings decoded from Options, e.g. an ExecSpec
+}
+
+// NewCheckSpec creates a CheckSpec for the given mount path, canary file and check type.
+// The canary path is only set for check types that read the canary file.
+func NewCheckSpec(path, canaryFile, checkType string) CheckSpec {
+	if checkType == "" {
+		checkType = CheckTypeCanary
+	}
+
+	canaryPath := ""
+	if checkType == CheckTypeCanary || checkType == CheckTypeContent {
+		canaryPath = path
+		if canaryFile != "" {
+			canaryPath = filepath.Join(path, canaryFile)
+		}
+	}
+	return CheckSpec{
+		CheckType:  checkType,
+		CanaryPath: canaryPath,
+	}
+}
+
+// verifiesMountpoint reports whether the check consults the mount table.
+func (s *CheckSpec) verifiesMountpoint() bool {
+	return s.Mountpoint.Require || s.CheckType == CheckTypeMountinfo
+}
+
+// CheckAllName takes the place of a mount name in the path of the API endpoint
+// that checks every mount, POST /api/v1/mounts/check, so no mount may be named this.
+const CheckAllName = "check"
+
 // Mount represents a single mount point being monitored.
+//
+// A mount runs either its single embedded CheckSpec or, if Checks is non-empty,
+// the composite list of checks combined according to CheckMode.
 type Mount struct {
-	Name             string       // Human-readable identifier (optional)
-	Path             string       // Absolute path to mount point
-	CanaryPath       string       // Full path to canary file
-	CheckType        string       // Type of health check to perform
-	FailureThreshold int          // Consecutive failures before unhealthy (per-mount)
-	Status           HealthStatus // Current health status
-	LastCheck        time.Time    // Timestamp of last health check
-	LastError        error        // Last error encountered (nil if healthy)
-	FailureCount     int          // Consecutive failure count for threshold
-	mu               sync.RWMutex // Protects all fields
+	CheckSpec                                         // Single check configuration (used when Checks is empty)
+	Name              string                          // Human-readable identifier (optional)
+	Path              string                          // Absolute path to mount point
+	Checks            []CheckSpec                     // Composite checks, run in order (optional)
+	CheckMode         string                          // How composite results combine: "all" (default) or "any"
+	FailureThreshold  int                             // Consecutive failures before unhealthy (per-mount)
+	MountInfo         *MountInfo                      // Last observed mount table entry (nil if not verified)
+	LastChecks        []SubCheckResult                // Per-check results of the last composite check
+	Dependencies      []DependencyResult              // Last results of checks that probe a dependency of the mount
+	RcloneStats       *RcloneStats                    // Last rclone statistics from an rclone-rc check (nil if none)
+	Status            HealthStatus                    // Current health status
+	LastCheck         time.Time                       // Timestamp of last health check
+	LastError         error                           // Last error encountered (nil if healthy)
+	LastReason        FailureReason                   // Classification of LastError (ReasonNone if healthy)
+	FailureCount      int                             // Consecutive failure count for threshold (kept while recovering)
+	SuccessCount      int                             // Consecutive passing checks since the last failure
+	RecoveryThreshold int                             // Consecutive passing checks before a failing mount is healthy again (0 or 1 = immediately)
+	FailureWindow     FailureWindow                   // Sliding-window failure counting, replaces FailureThreshold if enabled (optional)
+	FailurePolicies   map[FailureReason]FailurePolicy // Per-reason overrides of how failures count (optional)
+	Latency           LatencyPolicy                   // Latency thresholds for degrading or failing slow checks (optional)
+	IsolateProbes     bool                            // Run each check in a probe helper subprocess that is killed on timeout (see ProbeSubcommand)
+	Intervals         AdaptiveInterval                // State-dependent check intervals (optional, base interval by default)
+	FlapPolicy        FlapPolicy                      // Flap detection settings (optional, disabled by default)
+	flap              *FlapDetector                   // Transition history, created on first update if FlapPolicy is enabled
+	window            []bool                          // Results of the last FailureWindow.Size checks, oldest first (true = passed)
+	latencies         []time.Duration                 // Durations of the most recent checks, oldest first
+	slow              bool                            // Last check passed but exceeded Latency.Warn, or Latency.Fail without failing
+	slowChecks        int                             // Consecutive checks that exceeded Latency.Fail
+	watchdogSkipped   bool                            // Unhealthy only through failures matching a SkipWatchdog rule
+	probes            map[uint64]*probe               // Check goroutines that have not returned yet, by probe sequence number
+	probeSeq          uint64                          // Sequence number of the last probe started
+
+	// Adaptive check interval, as last chosen by NextInterval. The mode decides
+	// only how long the monitor waits before the next check: IntervalNormal uses
+	// the monitor's base interval, IntervalDegraded uses Intervals.Degraded while
+	// the mount is degraded, unhealthy or recovering, and IntervalStable uses
+	// Intervals.Stable once a healthy mount passed Intervals.StableAfter checks
+	// in a row. The first failure or full recovery switches the mode back; each
+	// switch is logged and reported in the mount's status.
+	interval      time.Duration // Wait until the next check in intervalMode (0 until the first check is scheduled)
+	intervalMode  IntervalMode  // Mode in effect since intervalSince
+	intervalSince time.Time     // LastCheck when the mount switched to intervalMode
+
+	mu sync.RWMutex // Protects all fields
 }
 
 // NewMount creates a new Mount instance.
@@ -68,27 +194,52 @@ func NewMount(name, path, canaryFile string, failureThreshold int) *Mount {
 
 // NewMountWithCheckType creates a new Mount instance with an explicit check type.
 func NewMountWithCheckType(name, path, canaryFile, checkType string, failureThreshold int) *Mount {
-	if checkType == "" {
-		checkType = CheckTypeCanary
-	}
-
-	canaryPath := ""
-	if checkType == CheckTypeCanary {
-		canaryPath = path
-		if canaryFile != "" {
-			canaryPath = filepath.Join(path, canaryFile)
-		}
-	}
 	return &Mount{
+		CheckSpec:        NewCheckSpec(path, canaryFile, checkType),
 		Name:             name,
 		Path:             path,
-		CanaryPath:       canaryPath,
-		CheckType:        checkType,
 		FailureThreshold: failureThreshold,
 		Status:           StatusUnknown,
 	}
 }
 
+// IsComposite reports whether the mount runs a list of checks rather than a single check.
+func (m *Mount) IsComposite() bool {
+	return len(m.Checks) > 0
+}
+
+// checkSpecs returns the checks to run for this mount, in order.
+func (m *Mount) checkSpecs() []CheckSpec {
+	if m.IsComposite() {
+		return m.Checks
+	}
+	return []CheckSpec{m.CheckSpec}
+}
+
+// verifiesMountpoint reports whether any of the mount's checks consult the mount table.
+func (m *Mount) verifiesMountpoint() bool {
+	for _, spec := range m.checkSpecs() {
+		if spec.verifiesMountpoint() {
+			return true
+		}
+	}
+	return false
+}
+
+// FailurePolicy overrides how failures with a particular FailureReason count
+// toward marking a mount unhealthy.
+type FailurePolicy struct {
+	// Threshold is the number of consecutive failures before the mount becomes
+	// unhealthy when the latest failure has this reason (0 = use the mount's
+	// threshold, 1 = unhealthy immediately).
+	Threshold int
+
+	// SkipWatchdog marks the mount unhealthy (failing readiness) without asking
+	// the watchdog to remediate or restart, for failures a restart cannot fix,
+	// such as permission errors.
+	SkipWatchdog bool
+}
+
 // GetName returns the mount name thread-safely.
 func (m *Mount) GetName() string {
 	m.mu.RLock()
@@ -103,6 +254,21 @@ func (m *Mount) GetStatus() HealthStatus {
 	return m.Status
 }
 
+// IsFlapping reports thread-safely whether flap detection considers the mount flapping.
+func (m *Mount) IsFlapping() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.flap != nil && m.flap.Flapping()
+}
+
+// WatchdogSkipped reports thread-safely whether the mount is unhealthy only
+// through failures matching a SkipWatchdog rule, so the watchdog was not told.
+func (m *Mount) WatchdogSkipped() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.watchdogSkipped
+}
+
 // GetFailureCount returns the current failure count thread-safely.
 func (m *Mount) GetFailureCount() int {
 	m.mu.RLock()
@@ -126,11 +292,46 @@ func (m *Mount) GetLastError() error {
 
 // CheckResult represents the outcome of a single health check.
 type CheckResult struct {
-	Mount     *Mount        // Reference to the mount checked
-	Timestamp time.Time     // When the check was performed
-	Success   bool          // Whether the canary file was readable
+	Mount        *Mount             // Reference to the mount checked
+	Timestamp    time.Time          // When the check was performed
+	Success      bool               // Whether the canary file was readable
+	Duration     time.Duration      // How long the check took
+	Error        error              // Error if check failed (nil on success)
+	Reason       FailureReason      // Classification of Error (ReasonNone on success)
+	BytesRead    int64              // Bytes read from the mount (range-read check only)
+	Throughput   float64            // Read throughput in bytes per second (0 if no bytes were read)
+	MountInfo    *MountInfo         // Mount table entry found during mountpoint verification (nil if not verified or not mounted)
+	SubResults   []SubCheckResult   // Per-check results for composite mounts (nil for single-check mounts)
+	Dependencies []DependencyResult // Results of checks that probe a dependency of the mount (see DependencyProvider)
+	Rclone       *RcloneStats       // rclone statistics gathered by an rclone-rc check (nil if not gathered)
+}
+
+// DependencyResult represents the outcome of a check that probes a dependency of
+// the mount, such as the WebDAV server behind it.
+type DependencyResult struct {
+	SubCheckResult
+	Target string // Identifier of the dependency, e.g. its URL without credentials
+}
+
+// SubCheckResult represents the outcome of one check within a composite check.
+type SubCheckResult struct {
+	CheckType string        // Type of the check
+	Success   bool          // Whether the check passed
+	Skipped   bool          // Check was not run because the composite outcome was already decided
 	Duration  time.Duration // How long the check took
-	Error     error         // Error if check failed (nil on success)
+	Error     error         // Error if check failed (nil on success or skip)
+}
+
+// Status returns "passed", "failed" or "skipped".
+func (r SubCheckResult) Status() string {
+	switch {
+	case r.Skipped:
+		return "skipped"
+	case r.Success:
+		return "passed"
+	default:
+		return "failed"
+	}
 }
 
 // StateTransition records a change in health status.
@@ -139,7 +340,8 @@ type StateTransition struct {
 	Timestamp     time.Time    // When the transition occurred
 	PreviousState HealthStatus // State before transition
 	NewState      HealthStatus // State after transition
-	Trigger       string       // What caused the transition
+	Trigger       string       // What caused the transition, e.g. "check_failed" or "rule:not_connected"
+	SkipWatchdog  bool         // The failure matched a rule with SkipWatchdog; the watchdog should not act on it
 }
 
 // UpdateState updates the mount's state based on a check result.
@@ -150,31 +352,123 @@ func (m *Mount) UpdateState(result *CheckResult, failureThreshold int) *StateTra
 
 	previousState := m.Status
 	m.LastCheck = result.Timestamp
+	if m.verifiesMountpoint() {
+		m.MountInfo = result.MountInfo
+	}
+	if m.IsComposite() {
+		m.LastChecks = result.SubResults
+	}
+	m.Dependencies = result.Dependencies
+	m.RcloneStats = result.Rclone
+
+	m.recordWindowLocked(result.Success)
+	if result.Reason != ReasonProbeHung {
+		// No probe ran, so there is no duration to sample
+		m.recordLatencyLocked(result.Duration)
+	}
+	m.slow = m.Latency.isSlow(result)
 
+	var matchedRule, skipWatchdog, windowExceeded bool
 	if result.Success {
-		// Check passed - reset to healthy
-		m.FailureCount = 0
+		m.SuccessCount++
 		m.LastError = nil
-		m.Status = StatusHealthy
+		m.LastReason = ReasonNone
+
+		if m.windowExceededLocked() {
+			// Too many of the recent checks failed for one success to count
+			windowExceeded = true
+			m.Status = StatusUnhealthy
+		} else if m.FailureCount == 0 || m.SuccessCount >= m.RecoveryThreshold {
+			// Check passed - reset to healthy
+			m.FailureCount = 0
+			m.Status = StatusHealthy
+			if m.slow {
+				// Slow checks degrade the mount without counting toward unhealthy
+				m.Status = StatusDegraded
+			}
+		} else {
+			// A failing mount must pass RecoveryThreshold checks in a row. The
+			// failure count is kept, so a failure in the meantime returns the
+			// mount to the state it was recovering from.
+			m.Status = StatusRecovering
+		}
 	} else {
 		// Check failed
+		m.SuccessCount = 0
 		m.FailureCount++
 		m.LastError = result.Error
+		m.LastReason = result.Reason
+		if m.LastReason == ReasonNone {
+			m.LastReason = ClassifyFailure(result.Error)
+		}
+		if m.LastReason == ReasonNone {
+			// Failed without an error; still report it as a failure
+			m.LastReason = ReasonOther
+		}
 
-		if m.FailureCount >= failureThreshold {
+		threshold := failureThreshold
+		ruleThreshold := false
+		if policy, ok := m.FailurePolicies[m.LastReason]; ok {
+			matchedRule = true
+			skipWatchdog = policy.SkipWatchdog
+			if policy.Threshold > 0 {
+				threshold = policy.Threshold
+				ruleThreshold = true
+			}
+		}
+		switch {
+		case m.FailureWindow.Enabled() && !ruleThreshold:
+			// Failure rules with their own threshold still count consecutive failures
+			windowExceeded = m.windowExceededLocked()
+			if windowExceeded {
+				m.Status = StatusUnhealthy
+			} else {
+				m.Status = StatusDegraded
+			}
+		case m.FailureCount >= threshold:
 			m.Status = StatusUnhealthy
-		} else {
+		default:
 			m.Status = StatusDegraded
 		}
 	}
 
+	switch {
+	case m.Status != StatusUnhealthy:
+		m.watchdogSkipped = false
+	case previousState != StatusUnhealthy:
+		m.watchdogSkipped = skipWatchdog
+	case !result.Success && !skipWatchdog:
+		// A failure the watchdog should act on ends an alert-only episode
+		m.watchdogSkipped = false
+	}
+
+	if m.FlapPolicy.Enabled() {
+		if m.flap == nil {
+			m.flap = NewFlapDetector(m.FlapPolicy)
+		}
+		// The first result of a new mount is not a state change worth counting
+		if m.Status != previousState && previousState != StatusUnknown {
+			m.flap.Record(result.Timestamp)
+		}
+		m.flap.Evaluate(result.Timestamp)
+	}
+
 	// Return transition if state changed
 	if m.Status != previousState {
 		trigger := "check_passed"
 		if !result.Success {
 			trigger = "check_failed"
 		}
-		if previousState == StatusUnhealthy && m.Status == StatusHealthy {
+		if matchedRule {
+			trigger = "rule:" + m.LastReason.String()
+		}
+		if windowExceeded && m.Status == StatusUnhealthy && !matchedRule {
+			trigger = "window_exceeded"
+		}
+		if m.slow && m.Status == StatusDegraded {
+			trigger = "slow_check"
+		}
+		if (previousState == StatusUnhealthy || previousState == StatusRecovering) && m.Status == StatusHealthy {
 			trigger = "recovered"
 		}
 		return &StateTransition{
@@ -183,6 +477,7 @@ func (m *Mount) UpdateState(result *CheckResult, failureThreshold int) *StateTra
 			PreviousState: previousState,
 			NewState:      m.Status,
 			Trigger:       trigger,
+			SkipWatchdog:  skipWatchdog,
 		}
 	}
 
@@ -191,12 +486,50 @@ func (m *Mount) UpdateState(result *CheckResult, failureThreshold int) *StateTra
 
 // Snapshot returns a thread-safe copy of mount state for reporting.
 type MountSnapshot struct {
-	Name         string
-	Path         string
-	Status       HealthStatus
-	LastCheck    time.Time
-	FailureCount int
-	LastError    string
+	Name          string
+	Path          string
+	Status        HealthStatus
+	LastCheck     time.Time
+	FailureCount  int
+	SuccessCount  int    // Consecutive passing checks (shown while recovering)
+	Flapping      bool   // Flap detection considers the mount flapping
+	Slow          bool   // Last check passed but exceeded a latency threshold
+	Window        []bool // Results of the last checks in failure window mode, oldest first (true = passed)
+	WindowSize    int    // Failure window size (0 if consecutive counting is used)
+	WindowLimit   int    // Failed checks within the window that make the mount unhealthy
+	Transitions   int    // State transitions within the flap detection window (0 if disabled)
+	LastError     string
+	LastReason    FailureReason        // Classification of the last error (ReasonNone if healthy)
+	MountSource   string               // Mount source from the mount table (empty if not verified)
+	FSType        string               // Filesystem type from the mount table (empty if not verified)
+	MountOptions  string               // Mount options from the mount table (empty if not verified)
+	CheckMode     string               // Composite check mode (empty for single-check mounts)
+	Checks        []SubCheckSnapshot   // Per-check results of the last composite check
+	Dependencies  []DependencySnapshot // Last results of checks that probe a dependency of the mount
+	Rclone        *RcloneStats         // Last rclone statistics (nil if not gathered)
+	Latency       LatencyStats         // Rolling percentiles of recent check durations
+	StuckProbes   int                  // Probes still blocked after their check timed out
+	StuckSince    time.Time            // Start time of the oldest stuck probe (zero if none)
+	Interval      time.Duration        // Effective interval until the next check (0 if not scheduled yet)
+	IntervalMode  IntervalMode         // Which interval applies
+	IntervalSince time.Time            // When the mount switched to IntervalMode
+}
+
+// DependencySnapshot is a point-in-time copy of a dependency check result.
+type DependencySnapshot struct {
+	CheckType string
+	Target    string
+	Status    string // "up", "down" or "unknown" (check was skipped)
+	Duration  time.Duration
+	Error     string
+}
+
+// SubCheckSnapshot is a point-in-time copy of a composite sub-check result.
+type SubCheckSnapshot struct {
+	CheckType string
+	Status    string // "passed", "failed" or "skipped"
+	Duration  time.Duration
+	Error     string
 }
 
 // Snapshot returns a point-in-time copy of the mount's state.
@@ -209,12 +542,74 @@ func (m *Mount) Snapshot() MountSnapshot {
 		errStr = m.LastError.Error()
 	}
 
-	return MountSnapshot{
+	snapshot := MountSnapshot{
 		Name:         m.Name,
 		Path:         m.Path,
 		Status:       m.Status,
 		LastCheck:    m.LastCheck,
 		FailureCount: m.FailureCount,
+		SuccessCount: m.SuccessCount,
 		LastError:    errStr,
+		LastReason:   m.LastReason,
+		Slow:         m.slow,
+		Latency:      m.latencyStatsLocked(),
+	}
+	snapshot.StuckSince, snapshot.StuckProbes = m.oldestStuckProbeLocked()
+	snapshot.Interval = m.interval
+	snapshot.IntervalMode = m.intervalMode
+	snapshot.IntervalSince = m.intervalSince
+	if m.FailureWindow.Enabled() {
+		snapshot.Window = append([]bool(nil), m.window...)
+		snapshot.WindowSize = m.FailureWindow.Size
+		snapshot.WindowLimit = m.FailureWindow.Failures
+	}
+	if m.flap != nil {
+		snapshot.Flapping = m.flap.Flapping()
+		snapshot.Transitions = m.flap.Transitions()
+	}
+	if m.IsComposite() {
+		snapshot.CheckMode = m.CheckMode
+		if snapshot.CheckMode == "" {
+			snapshot.CheckMode = CheckModeAll
+		}
+		for _, r := range m.LastChecks {
+			sub := SubCheckSnapshot{
+				CheckType: r.CheckType,
+				Status:    r.Status(),
+				Duration:  r.Duration,
+			}
+			if r.Error != nil {
+				sub.Error = r.Error.Error()
+			}
+			snapshot.Checks = append(snapshot.Checks, sub)
+		}
+	}
+	for _, d := range m.Dependencies {
+		dep := DependencySnapshot{
+			CheckType: d.CheckType,
+			Target:    d.Target,
+			Status:    "up",
+			Duration:  d.Duration,
+		}
+		switch {
+		case d.Skipped:
+			dep.Status = "unknown"
+		case !d.Success:
+			dep.Status = "down"
+		}
+		if d.Error != nil {
+			dep.Error = d.Error.Error()
+		}
+		snapshot.Dependencies = append(snapshot.Dependencies, dep)
+	}
+	if m.RcloneStats != nil {
+		stats := *m.RcloneStats
+		snapshot.Rclone = &stats
+	}
+	if m.MountInfo != nil {
+		snapshot.MountSource = m.MountInfo.Source
+		snapshot.FSType = m.MountInfo.FSType
+		snapshot.MountOptions = m.MountInfo.Options
 	}
+	return snapshot
 }
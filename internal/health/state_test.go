@@ -36,6 +36,15 @@ func TestNewMountWithCheckType_Directory(t *testing.T) {
 	is.Equal(mount.GetStatus(), health.StatusUnknown)    // initial status
 }
 
+func TestNewMountWithCheckType_Content(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("test-mount", "/mnt/test", ".health-check", health.CheckTypeContent, 3)
+
+	is.Equal(mount.CanaryPath, "/mnt/test/.health-check") // content check reads the canary file
+	is.Equal(mount.CheckType, health.CheckTypeContent)    // check type
+}
+
 func TestNewMount_TrailingSlash(t *testing.T) {
 	is := is.New(t)
 
@@ -53,6 +62,7 @@ func TestHealthStatus_String(t *testing.T) {
 		{health.StatusHealthy, "healthy"},
 		{health.StatusDegraded, "degraded"},
 		{health.StatusUnhealthy, "unhealthy"},
+		{health.StatusRecovering, "recovering"},
 		{health.HealthStatus(99), "unknown"}, // invalid value defaults to unknown
 	}
 
@@ -167,6 +177,44 @@ func TestMount_UpdateState_RecoveryFromUnhealthy(t *testing.T) {
 	is.Equal(transition.Trigger, "recovered")         // trigger should be recovered
 }
 
+func TestMount_UpdateState_RecoveryThreshold(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 1)
+	mount.RecoveryThreshold = 3
+	failed := func() *health.CheckResult {
+		return &health.CheckResult{Mount: mount, Timestamp: time.Now(), Error: errors.New("read timeout")}
+	}
+	passed := func() *health.CheckResult {
+		return &health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: true}
+	}
+
+	// First check of a new mount is healthy immediately
+	mount.UpdateState(passed(), 1)
+	is.Equal(mount.GetStatus(), health.StatusHealthy) // no recovery needed from unknown
+
+	mount.UpdateState(failed(), 1)
+	is.Equal(mount.GetStatus(), health.StatusUnhealthy) // unhealthy
+
+	transition := mount.UpdateState(passed(), 1)
+	is.True(transition != nil)                             // unhealthy -> recovering
+	is.Equal(transition.NewState, health.StatusRecovering) // recovering after first pass
+	is.Equal(mount.Snapshot().SuccessCount, 1)             // one pass counted
+
+	// A failure while recovering returns the mount to unhealthy
+	mount.UpdateState(failed(), 1)
+	is.Equal(mount.GetStatus(), health.StatusUnhealthy) // relapsed
+	is.Equal(mount.GetFailureCount(), 2)                // failure count kept while recovering
+
+	is.True(mount.UpdateState(passed(), 1) != nil) // unhealthy -> recovering
+	is.True(mount.UpdateState(passed(), 1) == nil) // still recovering
+	transition = mount.UpdateState(passed(), 1)
+	is.True(transition != nil)                          // recovering -> healthy
+	is.Equal(transition.NewState, health.StatusHealthy) // healthy after three passes
+	is.Equal(transition.Trigger, "recovered")           // trigger should be recovered
+	is.Equal(mount.GetFailureCount(), 0)                // failure count reset
+}
+
 func TestMount_UpdateState_NoTransitionOnSameState(t *testing.T) {
 	is := is.New(t)
 
@@ -0,0 +1,76 @@
+// Package health - test helpers
+//
+// This file exports internal functions for use in tests.
+// These functions should not be used in production code.
+package health
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// SetMountInfoPathForTesting points mountpoint verification at a fixture file
+// instead of /proc/self/mountinfo and returns a function that restores the default.
+//
+// WARNING: This function is intended for testing only.
+// Do not use in production code.
+func SetMountInfoPathForTesting(path string) (restore func()) {
+	previous := mountInfoPath
+	mountInfoPath = path
+	return func() { mountInfoPath = previous }
+}
+
+// SetProbeReturnHookForTesting sets a function that runs in the check goroutine
+// after it sent its last outcome and before it returns, and returns a function
+// that removes it.
+//
+// WARNING: This function is intended for testing only.
+// Do not use in production code.
+func SetProbeReturnHookForTesting(hook func()) (restore func()) {
+	probeReturnHook.Store(&hook)
+	return func() { probeReturnHook.Store(nil) }
+}
+
+// SetProbeWaitHookForTesting sets a function that runs before a probe helper is
+// waited for, and returns a function that removes it. A hook that blocks keeps
+// the helper from being reaped, like a helper stuck in uninterruptible sleep.
+//
+// WARNING: This function is intended for testing only.
+// Do not use in production code.
+func SetProbeWaitHookForTesting(hook func()) (restore func()) {
+	probeWaitHook.Store(&hook)
+	return func() { probeWaitHook.Store(nil) }
+}
+
+// BlockingCheck is a check provider for tests that ignores context
+// cancellation, like a read on a hung mount, and blocks until Release is called.
+// Register it under a test check type name with RegisterCheckType.
+//
+// WARNING: This type is intended for testing only.
+// Do not use in production code.
+type BlockingCheck struct {
+	release chan struct{}
+}
+
+// NewBlockingCheckForTesting creates a BlockingCheck whose checks block.
+//
+// WARNING: This function is intended for testing only.
+// Do not use in production code.
+func NewBlockingCheckForTesting() *BlockingCheck {
+	return &BlockingCheck{release: make(chan struct{})}
+}
+
+// Release lets every blocked and future check return, like a hung mount that
+// recovers. It must be called at most once.
+func (c *BlockingCheck) Release() {
+	close(c.release)
+}
+
+// Decode implements CheckProvider; the check has no settings.
+func (c *BlockingCheck) Decode(json.RawMessage) (any, error) { return nil, nil }
+
+// Run implements CheckProvider.
+func (c *BlockingCheck) Run(context.Context, string, *CheckSpec) (ProbeResult, error) {
+	<-c.release
+	return ProbeResult{}, nil
+}
@@ -0,0 +1,45 @@
+package health
+
+// FailureWindow configures sliding-window failure counting, an alternative to
+// consecutive-failure counting: the mount is unhealthy while at least Failures of
+// its last Size checks failed, so a mount failing most checks cannot stay out of
+// the unhealthy state through an occasional lucky success.
+type FailureWindow struct {
+	Size     int // Number of recent checks considered (0 disables the window)
+	Failures int // Failed checks within the window that make the mount unhealthy
+}
+
+// Enabled reports whether the window replaces consecutive-failure counting.
+func (w FailureWindow) Enabled() bool {
+	return w.Size > 0 && w.Failures > 0
+}
+
+// recordWindowLocked adds a check result to the mount's failure window, dropping
+// the oldest result once the window is full. Must be called with m.mu held.
+func (m *Mount) recordWindowLocked(success bool) {
+	if !m.FailureWindow.Enabled() {
+		return
+	}
+	m.window = append(m.window, success)
+	if excess := len(m.window) - m.FailureWindow.Size; excess > 0 {
+		m.window = append(m.window[:0], m.window[excess:]...)
+	}
+}
+
+// windowFailuresLocked returns the number of failed checks in the failure window.
+// Must be called with m.mu held.
+func (m *Mount) windowFailuresLocked() int {
+	failures := 0
+	for _, success := range m.window {
+		if !success {
+			failures++
+		}
+	}
+	return failures
+}
+
+// windowExceededLocked reports whether the failure window marks the mount
+// unhealthy. Must be called with m.mu held.
+func (m *Mount) windowExceededLocked() bool {
+	return m.FailureWindow.Enabled() && m.windowFailuresLocked() >= m.FailureWindow.Failures
+}
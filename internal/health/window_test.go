@@ -0,0 +1,63 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/matryer/is"
+)
+
+func TestMount_UpdateState_FailureWindow(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 3)
+	mount.FailureWindow = health.FailureWindow{Size: 5, Failures: 3}
+	check := func(success bool) *health.StateTransition {
+		result := &health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: success}
+		if !success {
+			result.Error = errors.New("read timeout")
+		}
+		return mount.UpdateState(result, 3)
+	}
+
+	// Failing most checks never reaches three consecutive failures
+	check(false)
+	check(true)
+	check(false)
+	is.Equal(mount.GetStatus(), health.StatusDegraded) // two of three failed
+
+	transition := check(false)
+	is.Equal(mount.GetStatus(), health.StatusUnhealthy) // three of four failed
+	is.Equal(transition.Trigger, "window_exceeded")     // window caused the transition
+
+	is.True(check(true) == nil)                         // a lucky success does not recover
+	is.Equal(mount.GetStatus(), health.StatusUnhealthy) // still three failures in window
+
+	snapshot := mount.Snapshot()
+	is.Equal(snapshot.WindowSize, 5)                                   // window size
+	is.Equal(snapshot.WindowLimit, 3)                                  // failures threshold
+	is.Equal(snapshot.Window, []bool{false, true, false, false, true}) // oldest first
+
+	check(true)                                       // oldest failure drops out: two failures in window
+	is.Equal(mount.GetStatus(), health.StatusHealthy) // recovered
+}
+
+func TestMount_UpdateState_FailureWindow_RuleThreshold(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 3)
+	mount.FailureWindow = health.FailureWindow{Size: 10, Failures: 5}
+	mount.FailurePolicies = map[health.FailureReason]health.FailurePolicy{
+		health.ReasonTimeout: {Threshold: 1},
+	}
+
+	transition := mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Error: errors.New("boom")}, 3)
+	is.Equal(transition.NewState, health.StatusDegraded) // window not exceeded
+
+	transition = mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Error: context.DeadlineExceeded}, 3)
+	is.Equal(transition.NewState, health.StatusUnhealthy) // rule threshold applies in window mode
+	is.Equal(transition.Trigger, "rule:timeout")          // rule recorded as trigger
+}
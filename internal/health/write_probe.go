@@ -0,0 +1,127 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// probeFilePrefix identifies files created by the write check.
+	// Only files with this prefix are ever removed by orphan cleanup.
+	probeFilePrefix = ".mount-monitor-probe-"
+
+	// orphanProbeAge is how old a probe file must be before it is considered orphaned.
+	// A live probe exists for at most one read timeout, so anything older was left
+	// behind by a crashed run. The margin keeps other replicas' in-flight probes safe.
+	orphanProbeAge = 10 * time.Minute
+)
+
+// WriteSpec describes where a write check creates its probe files.
+type WriteSpec struct {
+	ProbeDir string `json:"probeDir"` // Subdirectory relative to the mount path (empty = mount path)
+}
+
+// writeCheck round-trips a probe file through the mount.
+type writeCheck struct{}
+
+// Decode checks that the probe directory stays within the mount.
+func (writeCheck) Decode(options json.RawMessage) (any, error) {
+	w, err := decodeOptions[WriteSpec](options)
+	if err != nil {
+		return nil, err
+	}
+	if w.ProbeDir != "" {
+		if err := validateMountRelativePath("probeDir", w.ProbeDir); err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+func (writeCheck) Run(_ context.Context, path string, spec *CheckSpec) (ProbeResult, error) {
+	return ProbeResult{}, checkWrite(path, settingsOf[WriteSpec](spec).ProbeDir)
+}
+
+// checkWrite round-trips a uniquely named probe file through the mount:
+// create, write a random nonce, fsync, read back, compare and delete.
+func checkWrite(mountPath, probeDir string) (err error) {
+	dir := mountPath
+	if probeDir != "" {
+		dir = filepath.Join(mountPath, probeDir)
+	}
+
+	// Best effort: failure to clean up old probes should not fail the check itself
+	cleanupOrphanedProbes(dir, time.Now())
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("generating probe nonce: %w", err)
+	}
+	want := []byte(hex.EncodeToString(nonce))
+
+	f, err := os.CreateTemp(dir, probeFilePrefix+"*")
+	if err != nil {
+		return fmt.Errorf("creating probe file: %w", err)
+	}
+	probePath := f.Name()
+
+	// Always try to remove the probe file; a failed delete is reported only
+	// if everything else succeeded, since it indicates a broken mount too.
+	defer func() {
+		if rmErr := os.Remove(probePath); rmErr != nil && err == nil {
+			err = fmt.Errorf("removing probe file: %w", rmErr)
+		}
+	}()
+
+	if _, err := f.Write(want); err != nil {
+		f.Close()
+		return fmt.Errorf("writing probe file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("syncing probe file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing probe file: %w", err)
+	}
+
+	got, err := os.ReadFile(probePath)
+	if err != nil {
+		return fmt.Errorf("reading probe file: %w", err)
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("%w: probe file read back %d bytes that do not match the %d bytes written",
+			ErrContentMismatch, len(got), len(want))
+	}
+
+	return nil
+}
+
+// cleanupOrphanedProbes removes probe files older than orphanProbeAge from dir.
+func cleanupOrphanedProbes(dir string, now time.Time) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), probeFilePrefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if now.Sub(info.ModTime()) < orphanProbeAge {
+			continue
+		}
+		_ = os.Remove(filepath.Join(dir, entry.Name()))
+	}
+}
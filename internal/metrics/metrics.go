@@ -0,0 +1,352 @@
+// Package metrics collects mount health statistics and renders them in the
+// Prometheus text exposition format.
+//
+// This package intentionally avoids the Prometheus client library. The exposition
+// format is simple line-oriented text, and keeping the binary dependency-free matches
+// the approach taken by the watchdog's Kubernetes client.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/cscheib/debrid-mount-monitor/internal/watchdog"
+)
+
+// metricPrefix is prepended to every exported metric name.
+const metricPrefix = "mount_monitor_"
+
+// defaultBuckets are the histogram upper bounds (in seconds) for check durations.
+// These match the Prometheus client library defaults, which cover the range from
+// a cached canary read (a few ms) up to a check hitting a 10s read timeout.
+var defaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// WatchdogSource provides the current watchdog state for export.
+type WatchdogSource interface {
+	State() watchdog.WatchdogState
+}
+
+// mountKey identifies a mount in metric labels.
+type mountKey struct {
+	name string
+	path string
+}
+
+// checkKey identifies a check counter series.
+type checkKey struct {
+	mount      mountKey
+	result     string
+	errorClass string
+}
+
+// transitionKey identifies a state transition counter series.
+type transitionKey struct {
+	mount   mountKey
+	from    string
+	to      string
+	trigger string
+}
+
+// histogram is a fixed-bucket histogram of observed values.
+// counts[i] holds observations <= buckets[i] (non-cumulative); the +Inf bucket is total.
+type histogram struct {
+	counts []uint64
+	sum    float64
+	total  uint64
+}
+
+// Collector accumulates check results and state transitions and renders them,
+// together with current mount and watchdog state, as Prometheus metrics.
+// All methods are safe for concurrent use.
+type Collector struct {
+	mu          sync.Mutex
+	mounts      []*health.Mount
+	watchdog    WatchdogSource
+	buckets     []float64
+	durations   map[mountKey]*histogram
+	checks      map[checkKey]uint64
+	transitions map[transitionKey]uint64
+}
+
+// New creates a new Collector for the given mounts.
+func New(mounts []*health.Mount) *Collector {
+	return &Collector{
+		mounts:      mounts,
+		buckets:     defaultBuckets,
+		durations:   make(map[mountKey]*histogram),
+		checks:      make(map[checkKey]uint64),
+		transitions: make(map[transitionKey]uint64),
+	}
+}
+
+// SetWatchdog sets the watchdog whose state is exported.
+func (c *Collector) SetWatchdog(w WatchdogSource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.watchdog = w
+}
+
+// ObserveCheck records the outcome and duration of a single health check.
+func (c *Collector) ObserveCheck(result *health.CheckResult) {
+	if result == nil || result.Mount == nil {
+		return
+	}
+	key := keyFor(result.Mount)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ck := checkKey{mount: key, result: "success", errorClass: "none"}
+	if !result.Success {
+		ck.result = "failure"
+		ck.errorClass = ErrorClass(result.Error)
+	}
+	c.checks[ck]++
+
+	if result.Reason == health.ReasonProbeHung {
+		// No probe ran, so there is no duration to observe
+		return
+	}
+	h, ok := c.durations[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(c.buckets))}
+		c.durations[key] = h
+	}
+	seconds := result.Duration.Seconds()
+	for i, upper := range c.buckets {
+		if seconds <= upper {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.total++
+}
+
+// ObserveTransition records a mount state transition.
+func (c *Collector) ObserveTransition(transition *health.StateTransition) {
+	if transition == nil || transition.Mount == nil {
+		return
+	}
+	key := transitionKey{
+		mount:   keyFor(transition.Mount),
+		from:    transition.PreviousState.String(),
+		to:      transition.NewState.String(),
+		trigger: transition.Trigger,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.transitions[key]++
+}
+
+// ErrorClass maps a check error to a coarse, low-cardinality class suitable for
+// use as a metric label: the name of its health.FailureReason.
+func ErrorClass(err error) string {
+	return health.ClassifyFailure(err).String()
+}
+
+// WriteMetrics renders all metrics in Prometheus text exposition format (version 0.0.4).
+func (c *Collector) WriteMetrics(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+
+	// Mount gauges are read live from the mounts so they are always current.
+	writeHeader(bw, "mount_status", "gauge",
+		"Current mount health status (0=unknown, 1=healthy, 2=degraded, 3=unhealthy, 4=recovering).")
+	for _, m := range c.mounts {
+		snap := m.Snapshot()
+		writeSample(bw, "mount_status", mountLabels(mountKey{snap.Name, snap.Path}), float64(snap.Status))
+	}
+
+	writeHeader(bw, "mount_failure_count", "gauge",
+		"Consecutive failed health checks for the mount.")
+	for _, m := range c.mounts {
+		snap := m.Snapshot()
+		writeSample(bw, "mount_failure_count", mountLabels(mountKey{snap.Name, snap.Path}), float64(snap.FailureCount))
+	}
+
+	writeHeader(bw, "mount_flapping", "gauge",
+		"Whether flap detection considers the mount flapping (1) or not (0).")
+	for _, m := range c.mounts {
+		snap := m.Snapshot()
+		flapping := 0.0
+		if snap.Flapping {
+			flapping = 1
+		}
+		writeSample(bw, "mount_flapping", mountLabels(mountKey{snap.Name, snap.Path}), flapping)
+	}
+
+	writeHeader(bw, "mount_stuck_probes", "gauge",
+		"Probes of the mount still blocked after their check timed out.")
+	for _, m := range c.mounts {
+		snap := m.Snapshot()
+		writeSample(bw, "mount_stuck_probes", mountLabels(mountKey{snap.Name, snap.Path}), float64(snap.StuckProbes))
+	}
+
+	writeHeader(bw, "mount_stuck_probe_age_seconds", "gauge",
+		"Seconds the oldest stuck probe of the mount has been blocked (0 if none).")
+	for _, m := range c.mounts {
+		snap := m.Snapshot()
+		age := 0.0
+		if snap.StuckProbes > 0 {
+			age = time.Since(snap.StuckSince).Seconds()
+		}
+		writeSample(bw, "mount_stuck_probe_age_seconds", mountLabels(mountKey{snap.Name, snap.Path}), age)
+	}
+
+	writeHeader(bw, "check_duration_seconds", "histogram",
+		"Duration of mount health checks in seconds.")
+	for _, key := range sortedMountKeys(c.durations) {
+		h := c.durations[key]
+		labels := mountLabels(key)
+		var cumulative uint64
+		for i, upper := range c.buckets {
+			cumulative += h.counts[i]
+			writeSample(bw, "check_duration_seconds_bucket",
+				append(labels, label{"le", formatFloat(upper)}), float64(cumulative))
+		}
+		writeSample(bw, "check_duration_seconds_bucket", append(labels, label{"le", "+Inf"}), float64(h.total))
+		writeSample(bw, "check_duration_seconds_sum", labels, h.sum)
+		writeSample(bw, "check_duration_seconds_count", labels, float64(h.total))
+	}
+
+	writeHeader(bw, "checks_total", "counter",
+		"Total health checks by result and error class.")
+	checkKeys := make([]checkKey, 0, len(c.checks))
+	for k := range c.checks {
+		checkKeys = append(checkKeys, k)
+	}
+	sort.Slice(checkKeys, func(i, j int) bool {
+		a, b := checkKeys[i], checkKeys[j]
+		if a.mount != b.mount {
+			return lessMount(a.mount, b.mount)
+		}
+		if a.result != b.result {
+			return a.result < b.result
+		}
+		return a.errorClass < b.errorClass
+	})
+	for _, k := range checkKeys {
+		labels := append(mountLabels(k.mount), label{"result", k.result}, label{"error_class", k.errorClass})
+		writeSample(bw, "checks_total", labels, float64(c.checks[k]))
+	}
+
+	writeHeader(bw, "state_transitions_total", "counter",
+		"Total mount state transitions by previous state, new state and trigger.")
+	transitionKeys := make([]transitionKey, 0, len(c.transitions))
+	for k := range c.transitions {
+		transitionKeys = append(transitionKeys, k)
+	}
+	sort.Slice(transitionKeys, func(i, j int) bool {
+		a, b := transitionKeys[i], transitionKeys[j]
+		if a.mount != b.mount {
+			return lessMount(a.mount, b.mount)
+		}
+		if a.from != b.from {
+			return a.from < b.from
+		}
+		if a.to != b.to {
+			return a.to < b.to
+		}
+		return a.trigger < b.trigger
+	})
+	for _, k := range transitionKeys {
+		labels := append(mountLabels(k.mount), label{"from", k.from}, label{"to", k.to}, label{"trigger", k.trigger})
+		writeSample(bw, "state_transitions_total", labels, float64(c.transitions[k]))
+	}
+
+	if c.watchdog != nil {
+		state := c.watchdog.State()
+		writeHeader(bw, "watchdog_state", "gauge",
+			"Current watchdog state (0=disabled, 1=armed, 2=pending_restart, 3=triggered, 4=remediating).")
+		writeSample(bw, "watchdog_state", nil, float64(state.State))
+
+		writeHeader(bw, "watchdog_restarts_total", "counter",
+			"Total pod restarts triggered by the watchdog.")
+		writeSample(bw, "watchdog_restarts_total", nil, float64(state.RestartCount))
+
+		writeHeader(bw, "watchdog_remediations_total", "counter",
+			"Total remediation steps attempted by the watchdog.")
+		writeSample(bw, "watchdog_remediations_total", nil, float64(state.RemediationCount))
+	}
+
+	return bw.Flush()
+}
+
+// label is a single metric label name/value pair.
+type label struct {
+	name  string
+	value string
+}
+
+func keyFor(m *health.Mount) mountKey {
+	return mountKey{name: m.GetName(), path: m.Path}
+}
+
+func mountLabels(k mountKey) []label {
+	return []label{{"mount", k.name}, {"path", k.path}}
+}
+
+func lessMount(a, b mountKey) bool {
+	if a.name != b.name {
+		return a.name < b.name
+	}
+	return a.path < b.path
+}
+
+func sortedMountKeys(m map[mountKey]*histogram) []mountKey {
+	keys := make([]mountKey, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return lessMount(keys[i], keys[j]) })
+	return keys
+}
+
+func writeHeader(w *bufio.Writer, name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s%s %s\n", metricPrefix, name, help)
+	fmt.Fprintf(w, "# TYPE %s%s %s\n", metricPrefix, name, metricType)
+}
+
+func writeSample(w *bufio.Writer, name string, labels []label, value float64) {
+	w.WriteString(metricPrefix)
+	w.WriteString(name)
+	if len(labels) > 0 {
+		w.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(l.name)
+			w.WriteString(`="`)
+			w.WriteString(escapeLabelValue(l.value))
+			w.WriteByte('"')
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(value))
+	w.WriteByte('\n')
+}
+
+// labelValueEscaper escapes label values per the exposition format:
+// backslash, double-quote and line feed must be escaped.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelValueEscaper.Replace(v)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,155 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/cscheib/debrid-mount-monitor/internal/metrics"
+	"github.com/cscheib/debrid-mount-monitor/internal/watchdog"
+	"github.com/matryer/is"
+)
+
+// fakeWatchdog implements metrics.WatchdogSource for testing.
+type fakeWatchdog struct {
+	state watchdog.WatchdogState
+}
+
+func (f *fakeWatchdog) State() watchdog.WatchdogState { return f.state }
+
+func render(t *testing.T, c *metrics.Collector) string {
+	t.Helper()
+	var sb strings.Builder
+	if err := c.WriteMetrics(&sb); err != nil {
+		t.Fatalf("WriteMetrics failed: %v", err)
+	}
+	return sb.String()
+}
+
+func TestCollector_MountGauges(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: false}, 3)
+
+	out := render(t, metrics.New([]*health.Mount{mount}))
+
+	is.True(strings.Contains(out, "# TYPE mount_monitor_mount_status gauge"))                                          // status gauge type
+	is.True(strings.Contains(out, `mount_monitor_mount_status{mount="movies",path="/mnt/movies"} 2`))                  // degraded = 2
+	is.True(strings.Contains(out, `mount_monitor_mount_failure_count{mount="movies",path="/mnt/movies"} 1`))           // one failure
+	is.True(strings.Contains(out, `mount_monitor_mount_stuck_probes{mount="movies",path="/mnt/movies"} 0`))            // no stuck probes
+	is.True(strings.Contains(out, `mount_monitor_mount_stuck_probe_age_seconds{mount="movies",path="/mnt/movies"} 0`)) // no stuck probe age
+}
+
+func TestCollector_ObserveCheck_ProbeHung(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	c := metrics.New([]*health.Mount{mount})
+
+	c.ObserveCheck(&health.CheckResult{Mount: mount, Error: health.ErrProbeHung, Reason: health.ReasonProbeHung})
+
+	out := render(t, c)
+
+	labels := `mount="movies",path="/mnt/movies"`
+	is.True(strings.Contains(out, `mount_monitor_checks_total{`+labels+`,result="failure",error_class="probe_hung"} 1`)) // failure counted
+	is.True(!strings.Contains(out, `mount_monitor_check_duration_seconds_count{`+labels+`}`))                            // no duration observed
+}
+
+func TestCollector_ObserveCheck(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	c := metrics.New([]*health.Mount{mount})
+
+	c.ObserveCheck(&health.CheckResult{Mount: mount, Success: true, Duration: 20 * time.Millisecond})
+	c.ObserveCheck(&health.CheckResult{Mount: mount, Success: true, Duration: 3 * time.Second})
+	c.ObserveCheck(&health.CheckResult{Mount: mount, Success: false, Duration: 5 * time.Second, Error: context.DeadlineExceeded})
+
+	out := render(t, c)
+
+	labels := `mount="movies",path="/mnt/movies"`
+	is.True(strings.Contains(out, "# TYPE mount_monitor_check_duration_seconds histogram"))                           // histogram type
+	is.True(strings.Contains(out, `mount_monitor_check_duration_seconds_bucket{`+labels+`,le="0.01"} 0`))             // below first sample
+	is.True(strings.Contains(out, `mount_monitor_check_duration_seconds_bucket{`+labels+`,le="0.025"} 1`))            // 20ms sample
+	is.True(strings.Contains(out, `mount_monitor_check_duration_seconds_bucket{`+labels+`,le="5"} 3`))                // cumulative
+	is.True(strings.Contains(out, `mount_monitor_check_duration_seconds_bucket{`+labels+`,le="+Inf"} 3`))             // all samples
+	is.True(strings.Contains(out, `mount_monitor_check_duration_seconds_count{`+labels+`} 3`))                        // count
+	is.True(strings.Contains(out, `mount_monitor_checks_total{`+labels+`,result="success",error_class="none"} 2`))    // successes
+	is.True(strings.Contains(out, `mount_monitor_checks_total{`+labels+`,result="failure",error_class="timeout"} 1`)) // timeout failure
+}
+
+func TestCollector_ObserveTransition(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/tv", ".health-check", 1)
+	c := metrics.New([]*health.Mount{mount})
+
+	transition := mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: false}, 1)
+	is.True(transition != nil) // unknown -> unhealthy
+	c.ObserveTransition(transition)
+	c.ObserveTransition(nil) // nil transitions are ignored
+
+	out := render(t, c)
+
+	is.True(strings.Contains(out,
+		`mount_monitor_state_transitions_total{mount="",path="/mnt/tv",from="unknown",to="unhealthy",trigger="check_failed"} 1`)) // transition counted
+}
+
+func TestCollector_WatchdogMetrics(t *testing.T) {
+	is := is.New(t)
+
+	c := metrics.New(nil)
+	out := render(t, c)
+	is.True(!strings.Contains(out, "mount_monitor_watchdog_state")) // omitted without watchdog
+
+	c.SetWatchdog(&fakeWatchdog{state: watchdog.WatchdogState{State: watchdog.WatchdogTriggered, RestartCount: 1, RemediationCount: 2}})
+	out = render(t, c)
+
+	is.True(strings.Contains(out, "mount_monitor_watchdog_state 3\n"))              // triggered = 3
+	is.True(strings.Contains(out, "mount_monitor_watchdog_restarts_total 1\n"))     // one restart
+	is.True(strings.Contains(out, "mount_monitor_watchdog_remediations_total 2\n")) // two remediation steps
+}
+
+func TestCollector_EscapesLabelValues(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("a\"b\\c\nd", "/mnt/x", ".health-check", 3)
+	out := render(t, metrics.New([]*health.Mount{mount}))
+
+	is.True(strings.Contains(out, `mount="a\"b\\c\nd"`)) // quotes, backslashes and newlines escaped
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "none"},
+		{context.DeadlineExceeded, "timeout"},
+		{context.Canceled, "canceled"},
+		{fmt.Errorf("open: %w", fs.ErrNotExist), "not_found"},
+		{fmt.Errorf("open: %w", fs.ErrPermission), "permission"},
+		{&fs.PathError{Op: "open", Path: "/mnt/x", Err: syscall.ENOTCONN}, "not_connected"},
+		{fmt.Errorf("%w: exited with code 1", health.ErrExecFailed), "exec_failed"},
+		{fmt.Errorf("%w: 503 Service Unavailable", health.ErrUnexpectedHTTPStatus), "http_status"},
+		{fmt.Errorf("%w: /mnt/debrid", health.ErrRcloneMountMissing), "rclone_mount_missing"},
+		{fmt.Errorf("%w: 12 uploads queued", health.ErrRcloneUploadQueue), "rclone_upload_queue"},
+		{fmt.Errorf("%w: took 6s, limit 5s", health.ErrSlowCheck), "slow"},
+		{fmt.Errorf("%w for 2m0s", health.ErrProbeHung), "probe_hung"},
+		{errors.New("boom"), "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(metrics.ErrorClass(tt.err), tt.want)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package monitor
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"math/rand"
 	"sync"
@@ -17,22 +18,40 @@ const (
 	jitterFactor = 0.1
 )
 
-// WatchdogNotifier is an interface for notifying the watchdog of mount state changes.
+// ErrUnknownMount is returned by CheckNow for a mount the monitor does not check.
+var ErrUnknownMount = errors.New("mount is not monitored")
+
+// WatchdogNotifier is an interface for notifying the watchdog of check results
+// and mount state changes.
 type WatchdogNotifier interface {
+	OnMountChecked(mountPath string, checkErr error)
 	OnMountUnhealthy(mountPath string, failureCount int)
 	OnMountHealthy(mountPath string)
 }
 
+// MetricsRecorder is an interface for recording check results and state transitions.
+type MetricsRecorder interface {
+	ObserveCheck(result *health.CheckResult)
+	ObserveTransition(transition *health.StateTransition)
+}
+
 // Monitor continuously checks mount health at configured intervals.
+//
+// Each mount is checked by its own scheduler goroutine, so a mount whose checks
+// hang until the read timeout never delays the checks of other mounts.
 type Monitor struct {
 	mounts           []*health.Mount
 	checker          *health.Checker
 	interval         time.Duration
 	failureThreshold int
+	maxConcurrent    int // Maximum checks running at once (0 = no limit beyond one per mount)
 	logger           *slog.Logger
 	wg               sync.WaitGroup
 	watchdog         WatchdogNotifier
-	rng              *rand.Rand // Per-instance random source for jitter (avoids global rand thread-safety issues)
+	metrics          MetricsRecorder
+	rngMu            sync.Mutex                      // Protects rng, which the per-mount schedulers share
+	rng              *rand.Rand                      // Per-instance random source for jitter (avoids global rand thread-safety issues)
+	checking         map[*health.Mount]chan struct{} // Held while a mount is being checked, so it is never probed concurrently
 }
 
 // New creates a new Monitor instance.
@@ -44,7 +63,18 @@ func New(mounts []*health.Mount, checker *health.Checker, interval time.Duration
 		failureThreshold: failureThreshold,
 		logger:           logger,
 		rng:              rand.New(rand.NewSource(time.Now().UnixNano())),
+		checking:         checkingLocks(mounts),
+	}
+}
+
+// checkingLocks returns a lock for each mount. Each lock is a channel with room
+// for one token, so waiting for it can be abandoned when a context is cancelled.
+func checkingLocks(mounts []*health.Mount) map[*health.Mount]chan struct{} {
+	locks := make(map[*health.Mount]chan struct{}, len(mounts))
+	for _, mount := range mounts {
+		locks[mount] = make(chan struct{}, 1)
 	}
+	return locks
 }
 
 // SetWatchdog sets the watchdog notifier for mount state changes.
@@ -52,13 +82,26 @@ func (m *Monitor) SetWatchdog(w WatchdogNotifier) {
 	m.watchdog = w
 }
 
+// SetMetrics sets the recorder for check results and state transitions.
+func (m *Monitor) SetMetrics(r MetricsRecorder) {
+	m.metrics = r
+}
+
+// SetMaxConcurrent limits how many mount checks run at the same time. A value
+// of 0 (the default) runs every mount's checks independently. It must be called
+// before Start.
+func (m *Monitor) SetMaxConcurrent(n int) {
+	m.maxConcurrent = n
+}
+
 // Start begins the health check loop. It runs until the context is cancelled.
 func (m *Monitor) Start(ctx context.Context) {
 	m.wg.Add(1)
 	go m.run(ctx)
 }
 
-// Wait blocks until the monitor has stopped.
+// Wait blocks until the monitor has stopped, including any check in progress
+// when the context was cancelled.
 func (m *Monitor) Wait() {
 	m.wg.Wait()
 }
@@ -66,46 +109,123 @@ func (m *Monitor) Wait() {
 func (m *Monitor) run(ctx context.Context) {
 	defer m.wg.Done()
 
+	// A buffered channel acts as a semaphore bounding concurrent checks
+	var slots chan struct{}
+	if m.maxConcurrent > 0 {
+		slots = make(chan struct{}, m.maxConcurrent)
+	}
+
+	var schedulers sync.WaitGroup
+	for _, mount := range m.mounts {
+		schedulers.Add(1)
+		go func(mount *health.Mount) {
+			defer schedulers.Done()
+			m.runMount(ctx, mount, slots)
+		}(mount)
+	}
+	schedulers.Wait()
+	m.logger.Info("monitor shutting down")
+}
+
+// runMount checks a single mount until the context is cancelled, at an interval
+// that adapts to the mount's state (see health.AdaptiveInterval). Each mount
+// draws its own jitter, so mounts drift apart instead of being checked in lockstep.
+func (m *Monitor) runMount(ctx context.Context, mount *health.Mount, slots chan struct{}) {
 	// Perform initial check immediately
-	m.checkAll(ctx)
+	m.checkWhenSlotFree(ctx, mount, slots)
 
 	for {
-		// Apply jitter to prevent synchronized checks across multiple pods
-		jitteredInterval := m.intervalWithJitter()
+		interval, mode, changed := mount.NextInterval(m.interval)
+		if changed {
+			m.logIntervalChange(mount, interval, mode)
+		}
+		// Apply jitter to prevent synchronized checks across mounts and pods
+		jitteredInterval := m.intervalWithJitter(interval)
 
 		select {
 		case <-ctx.Done():
-			m.logger.Info("monitor shutting down")
 			return
 		case <-time.After(jitteredInterval):
-			m.checkAll(ctx)
+			m.checkWhenSlotFree(ctx, mount, slots)
 		}
 	}
 }
 
-// intervalWithJitter returns the check interval with ±10% random jitter applied.
-// This prevents synchronized load spikes when many pods start simultaneously.
-func (m *Monitor) intervalWithJitter() time.Duration {
-	// Calculate jitter range: ±jitterFactor of the interval
-	jitterRange := float64(m.interval) * jitterFactor
-	// Generate random offset in range [-jitterRange, +jitterRange]
-	// Uses per-instance rng to avoid global rand thread-safety issues
-	jitter := (m.rng.Float64()*2 - 1) * jitterRange
-	return m.interval + time.Duration(jitter)
-}
-
-func (m *Monitor) checkAll(ctx context.Context) {
-	for _, mount := range m.mounts {
+// checkWhenSlotFree checks the mount once fewer than maxConcurrent checks are
+// running and no on-demand check of the mount is in progress. It gives up
+// without checking if the context is cancelled first.
+func (m *Monitor) checkWhenSlotFree(ctx context.Context, mount *health.Mount, slots chan struct{}) {
+	if slots != nil {
 		select {
 		case <-ctx.Done():
 			return
-		default:
-			m.checkMount(ctx, mount)
+		case slots <- struct{}{}:
 		}
+		defer func() { <-slots }()
+	}
+	lock := m.checking[mount]
+	select {
+	case <-ctx.Done():
+		return
+	case lock <- struct{}{}:
+	}
+	defer func() { <-lock }()
+	if ctx.Err() != nil {
+		return
+	}
+	m.checkMount(ctx, mount)
+}
+
+// CheckNow checks the mount immediately, outside its schedule, and returns the
+// result. The result updates the mount's state, metrics and the watchdog like a
+// scheduled check. If the mount is already being checked, CheckNow waits for
+// that check to finish first; it returns ctx.Err() if ctx is cancelled while
+// waiting. Once started, the check is not cancelled with ctx, so a caller
+// giving up never records a cancelled check as a failure of the mount.
+// On-demand checks do not count toward maxConcurrent.
+func (m *Monitor) CheckNow(ctx context.Context, mount *health.Mount) (*health.CheckResult, error) {
+	lock, ok := m.checking[mount]
+	if !ok {
+		return nil, ErrUnknownMount
 	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case lock <- struct{}{}:
+	}
+	defer func() { <-lock }()
+	return m.checkMount(context.WithoutCancel(ctx), mount), nil
+}
+
+// logIntervalChange logs a mount switching to another check interval.
+func (m *Monitor) logIntervalChange(mount *health.Mount, interval time.Duration, mode health.IntervalMode) {
+	attrs := []any{
+		"path", mount.Path,
+		"mode", mode.String(),
+		"interval", interval.String(),
+	}
+	if mount.Name != "" {
+		attrs = append(attrs, "name", mount.Name)
+	}
+	m.logger.Info("check interval changed", attrs...)
+}
+
+// intervalWithJitter returns the given check interval with ±10% random jitter applied.
+// This prevents synchronized load spikes when many pods start simultaneously.
+func (m *Monitor) intervalWithJitter(interval time.Duration) time.Duration {
+	// Calculate jitter range: ±jitterFactor of the interval
+	jitterRange := float64(interval) * jitterFactor
+	// Generate random offset in range [-jitterRange, +jitterRange]
+	// Uses per-instance rng to avoid global rand thread-safety issues
+	m.rngMu.Lock()
+	jitter := (m.rng.Float64()*2 - 1) * jitterRange
+	m.rngMu.Unlock()
+	return interval + time.Duration(jitter)
 }
 
-func (m *Monitor) checkMount(ctx context.Context, mount *health.Mount) {
+// checkMount checks the mount, records the result and returns it. Callers must
+// hold the mount's checking lock.
+func (m *Monitor) checkMount(ctx context.Context, mount *health.Mount) *health.CheckResult {
 	result := m.checker.Check(ctx, mount)
 	// Use per-mount threshold if set (>0), otherwise fall back to global threshold.
 	// A threshold of 0 is a sentinel meaning "use default" - this is documented in
@@ -114,7 +234,17 @@ func (m *Monitor) checkMount(ctx context.Context, mount *health.Mount) {
 	if threshold == 0 {
 		threshold = m.failureThreshold
 	}
+	wasFlapping := mount.IsFlapping()
+	wasSkipped := mount.WatchdogSkipped()
 	transition := mount.UpdateState(result, threshold)
+	flapping := mount.IsFlapping()
+
+	if m.metrics != nil {
+		m.metrics.ObserveCheck(result)
+		if transition != nil {
+			m.metrics.ObserveTransition(transition)
+		}
+	}
 
 	// Log check result - include name if available for easier identification
 	logAttrs := []any{
@@ -128,8 +258,12 @@ func (m *Monitor) checkMount(ctx context.Context, mount *health.Mount) {
 		logAttrs = append(logAttrs, "name", mount.Name)
 	}
 
+	if result.BytesRead > 0 {
+		logAttrs = append(logAttrs, "bytes_read", result.BytesRead, "throughput_bps", int64(result.Throughput))
+	}
+
 	if result.Error != nil {
-		logAttrs = append(logAttrs, "error", result.Error.Error())
+		logAttrs = append(logAttrs, "error", result.Error.Error(), "reason", result.Reason.String())
 	}
 
 	if result.Success {
@@ -138,6 +272,11 @@ func (m *Monitor) checkMount(ctx context.Context, mount *health.Mount) {
 		m.logger.Warn("health check failed", logAttrs...)
 	}
 
+	// Notify watchdog of every check result, before any state transition it caused
+	if m.watchdog != nil {
+		m.watchdog.OnMountChecked(mount.Path, result.Error)
+	}
+
 	// Log state transitions - include name if available
 	if transition != nil {
 		transitionAttrs := []any{
@@ -149,15 +288,87 @@ func (m *Monitor) checkMount(ctx context.Context, mount *health.Mount) {
 		if mount.Name != "" {
 			transitionAttrs = append(transitionAttrs, "name", mount.Name)
 		}
-		m.logger.Info("mount state changed", transitionAttrs...)
-
-		// Notify watchdog of state transitions
-		if m.watchdog != nil {
-			if transition.NewState == health.StatusUnhealthy {
-				m.watchdog.OnMountUnhealthy(mount.Path, mount.GetFailureCount())
-			} else if transition.NewState == health.StatusHealthy && transition.PreviousState == health.StatusUnhealthy {
-				m.watchdog.OnMountHealthy(mount.Path)
-			}
+		if transition.SkipWatchdog {
+			transitionAttrs = append(transitionAttrs, "watchdog_skipped", true)
+		}
+		if flapping && wasFlapping {
+			// Damp log spam; flapping was reported when it started
+			m.logger.Debug("mount state changed", transitionAttrs...)
+		} else {
+			m.logger.Info("mount state changed", transitionAttrs...)
 		}
 	}
+
+	if flapping != wasFlapping {
+		m.logFlapping(mount, flapping)
+	}
+
+	if m.watchdog != nil {
+		m.notifyWatchdog(mount, transition, wasFlapping, flapping, wasSkipped)
+	}
+	return result
+}
+
+// logFlapping logs a mount starting or stopping to flap.
+func (m *Monitor) logFlapping(mount *health.Mount, flapping bool) {
+	snapshot := mount.Snapshot()
+	attrs := []any{
+		"path", mount.Path,
+		"status", snapshot.Status.String(),
+		"transitions", snapshot.Transitions,
+		"window", mount.FlapPolicy.Window.String(),
+	}
+	if mount.Name != "" {
+		attrs = append(attrs, "name", mount.Name)
+	}
+	if flapping {
+		m.logger.Warn("mount flapping", attrs...)
+	} else {
+		m.logger.Info("mount stopped flapping", attrs...)
+	}
+}
+
+// notifyWatchdog tells the watchdog about state transitions and, if the mount's
+// flap policy treats flapping as unhealthy, about flapping starting and stopping.
+// A mount that went unhealthy through an alert-only failure rule is reported
+// once a later failure is one the watchdog should act on.
+func (m *Monitor) notifyWatchdog(mount *health.Mount, transition *health.StateTransition, wasFlapping, flapping, wasSkipped bool) {
+	if mount.FlapPolicy.Unhealthy {
+		switch {
+		case flapping && !wasFlapping:
+			m.watchdog.OnMountUnhealthy(mount.Path, mount.GetFailureCount())
+			return
+		case flapping:
+			// Transitions of a flapping mount neither cancel nor re-trigger the watchdog
+			return
+		case wasFlapping && mount.GetStatus() != health.StatusUnhealthy:
+			m.watchdog.OnMountHealthy(mount.Path)
+			return
+		}
+	}
+
+	if transition == nil {
+		if wasSkipped && !mount.WatchdogSkipped() && mount.GetStatus() == health.StatusUnhealthy {
+			m.watchdog.OnMountUnhealthy(mount.Path, mount.GetFailureCount())
+		}
+		return
+	}
+	// Alert-only failure rules never trigger the watchdog
+	if transition.NewState == health.StatusUnhealthy && !transition.SkipWatchdog {
+		m.watchdog.OnMountUnhealthy(mount.Path, mount.GetFailureCount())
+	} else if recovered(transition) {
+		// Only cancel after full recovery, so a flapping mount does not
+		// keep cancelling the watchdog
+		m.watchdog.OnMountHealthy(mount.Path)
+	}
+}
+
+// recovered reports whether a transition took a failing mount back to passing
+// checks. A mount whose checks pass but are slow counts as recovered: slowness
+// alone is not something the watchdog should remediate.
+func recovered(transition *health.StateTransition) bool {
+	if transition.PreviousState != health.StatusUnhealthy && transition.PreviousState != health.StatusRecovering {
+		return false
+	}
+	return transition.NewState == health.StatusHealthy || transition.Trigger == "slow_check"
 }
@@ -2,15 +2,21 @@ package monitor_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sync"
 	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/cscheib/debrid-mount-monitor/internal/health"
 	"github.com/cscheib/debrid-mount-monitor/internal/monitor"
+	"github.com/cscheib/debrid-mount-monitor/internal/testutil"
 	"github.com/matryer/is"
 	"go.uber.org/goleak"
 )
@@ -157,10 +163,12 @@ func TestMonitor_MultipleMount(t *testing.T) {
 // mockWatchdog implements WatchdogNotifier for testing.
 // Uses atomic operations to be safe for concurrent access during race tests.
 type mockWatchdog struct {
+	checkedCalls   atomic.Int32
 	healthyCalls   atomic.Int32
 	unhealthyCalls atomic.Int32
 }
 
+func (m *mockWatchdog) OnMountChecked(mountPath string, checkErr error)     { m.checkedCalls.Add(1) }
 func (m *mockWatchdog) OnMountHealthy(mountPath string)                     { m.healthyCalls.Add(1) }
 func (m *mockWatchdog) OnMountUnhealthy(mountPath string, failureCount int) { m.unhealthyCalls.Add(1) }
 
@@ -192,6 +200,7 @@ func TestMonitor_SetWatchdog(t *testing.T) {
 	// Poll until mount becomes unhealthy (due to missing canary)
 	is.True(pollForStatus(t, mount, health.StatusUnhealthy, 5*time.Second, checkInterval))
 	is.True(watchdog.unhealthyCalls.Load() > 0) // watchdog should be notified of unhealthy
+	is.True(watchdog.checkedCalls.Load() > 0)   // watchdog should be notified of check results
 
 	// Now create the canary file and wait for recovery
 	if err := os.WriteFile(canaryPath, []byte("ok"), 0644); err != nil {
@@ -239,3 +248,493 @@ func TestMonitor_WatchdogNotifiedOnStateChange(t *testing.T) {
 	// Watchdog should have been notified of unhealthy state
 	is.True(watchdog.unhealthyCalls.Load() > 0) // watchdog should be notified of unhealthy mount
 }
+
+// TestMonitor_SkipWatchdogRule tests that failures matching an alert-only rule
+// mark the mount unhealthy without notifying the watchdog.
+func TestMonitor_SkipWatchdogRule(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	// Don't create canary file - checks fail with not_found
+
+	mount := health.NewMount("alert-mount", tmpDir, ".health-check", 3)
+	mount.FailurePolicies = map[health.FailureReason]health.FailurePolicy{
+		health.ReasonNotFound: {Threshold: 1, SkipWatchdog: true},
+	}
+	checker := health.NewChecker(100 * time.Millisecond)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	checkInterval := 50 * time.Millisecond
+	mon := monitor.New([]*health.Mount{mount}, checker, checkInterval, 3, logger)
+
+	watchdog := &mockWatchdog{}
+	mon.SetWatchdog(watchdog)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mon.Start(ctx)
+
+	is.True(pollForStatus(t, mount, health.StatusUnhealthy, 5*time.Second, checkInterval))
+
+	cancel()
+	mon.Wait()
+
+	is.Equal(watchdog.unhealthyCalls.Load(), int32(0)) // watchdog must not be notified
+	is.True(watchdog.checkedCalls.Load() > 0)          // check results are still reported
+}
+
+// TestMonitor_SkipWatchdogRule_Escalates tests that a mount made unhealthy by
+// an alert-only rule notifies the watchdog once a later failure is one the
+// watchdog should act on.
+func TestMonitor_SkipWatchdogRule_Escalates(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", "test-failing", 3)
+	mount.FailurePolicies = map[health.FailureReason]health.FailurePolicy{
+		health.ReasonNotFound:     {Threshold: 1, SkipWatchdog: true},
+		health.ReasonNotConnected: {Threshold: 1},
+	}
+	checker := health.NewChecker(time.Second)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	mon := monitor.New([]*health.Mount{mount}, checker, time.Hour, 3, logger)
+	watchdog := &mockWatchdog{}
+	mon.SetWatchdog(watchdog)
+
+	check := func(err error) {
+		t.Helper()
+		failingCheck.err.Store(&err)
+		_, checkErr := mon.CheckNow(context.Background(), mount)
+		is.NoErr(checkErr) // check should run
+	}
+
+	check(&fs.PathError{Op: "open", Path: mount.Path, Err: syscall.ENOENT})
+	is.Equal(mount.GetStatus(), health.StatusUnhealthy) // alert-only rule marks the mount unhealthy
+	is.Equal(watchdog.unhealthyCalls.Load(), int32(0))  // watchdog not told
+
+	check(&fs.PathError{Op: "open", Path: mount.Path, Err: syscall.ENOTCONN})
+	is.Equal(mount.GetStatus(), health.StatusUnhealthy) // still unhealthy, no transition
+	is.Equal(watchdog.unhealthyCalls.Load(), int32(1))  // watchdog told once the failure is not alert-only
+
+	check(&fs.PathError{Op: "open", Path: mount.Path, Err: syscall.ENOTCONN})
+	check(&fs.PathError{Op: "open", Path: mount.Path, Err: syscall.ENOENT})
+	is.Equal(watchdog.unhealthyCalls.Load(), int32(1)) // told only once per unhealthy episode
+}
+
+// recoveryWatchdog records the mount status whenever the watchdog is told a
+// mount recovered.
+type recoveryWatchdog struct {
+	mockWatchdog
+	mount            *health.Mount
+	statusAtRecovery atomic.Int32
+}
+
+func (r *recoveryWatchdog) OnMountHealthy(mountPath string) {
+	r.statusAtRecovery.Store(int32(r.mount.GetStatus()))
+	r.mockWatchdog.OnMountHealthy(mountPath)
+}
+
+// TestMonitor_WatchdogNotifiedAfterFullRecovery tests that the watchdog is only
+// told a mount recovered once it passed recoveryThreshold checks in a row.
+func TestMonitor_WatchdogNotifiedAfterFullRecovery(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	canaryPath := filepath.Join(tmpDir, ".health-check")
+
+	mount := health.NewMount("recovering-mount", tmpDir, ".health-check", 1)
+	mount.RecoveryThreshold = 3
+	checker := health.NewChecker(100 * time.Millisecond)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	checkInterval := 50 * time.Millisecond
+	mon := monitor.New([]*health.Mount{mount}, checker, checkInterval, 1, logger)
+
+	watchdog := &recoveryWatchdog{mount: mount}
+	mon.SetWatchdog(watchdog)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mon.Start(ctx)
+
+	is.True(pollForStatus(t, mount, health.StatusUnhealthy, 5*time.Second, checkInterval))
+
+	if err := os.WriteFile(canaryPath, []byte("ok"), 0644); err != nil {
+		t.Fatalf("failed to create canary file: %v", err)
+	}
+
+	is.True(pollForStatus(t, mount, health.StatusHealthy, 5*time.Second, checkInterval))
+
+	cancel()
+	mon.Wait()
+
+	is.Equal(watchdog.healthyCalls.Load(), int32(1))                                      // notified once
+	is.Equal(health.HealthStatus(watchdog.statusAtRecovery.Load()), health.StatusHealthy) // only after full recovery
+}
+
+// TestMonitor_SlowRecoveryNotifiesWatchdog tests that a failing mount whose checks
+// pass again but slowly counts as recovered for the watchdog, even though it stays
+// degraded.
+func TestMonitor_SlowRecoveryNotifiesWatchdog(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	canaryPath := filepath.Join(tmpDir, ".health-check")
+
+	mount := health.NewMount("slow-mount", tmpDir, ".health-check", 1)
+	mount.Latency = health.LatencyPolicy{Warn: time.Nanosecond} // every passing check is slow
+	checker := health.NewChecker(100 * time.Millisecond)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	checkInterval := 50 * time.Millisecond
+	mon := monitor.New([]*health.Mount{mount}, checker, checkInterval, 1, logger)
+
+	watchdog := &mockWatchdog{}
+	mon.SetWatchdog(watchdog)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mon.Start(ctx)
+
+	is.True(pollForStatus(t, mount, health.StatusUnhealthy, 5*time.Second, checkInterval))
+
+	if err := os.WriteFile(canaryPath, []byte("ok"), 0644); err != nil {
+		t.Fatalf("failed to create canary file: %v", err)
+	}
+
+	is.True(pollForStatus(t, mount, health.StatusDegraded, 5*time.Second, checkInterval))
+	// The watchdog is told after the state changed
+	testutil.PollUntil(t, 5*time.Second, func() bool { return watchdog.healthyCalls.Load() > 0 })
+
+	// Read the calls before stopping, which may cancel a check in progress
+	is.Equal(watchdog.unhealthyCalls.Load(), int32(1)) // notified of the failure
+	is.Equal(watchdog.healthyCalls.Load(), int32(1))   // slow but passing checks cancel the watchdog
+
+	cancel()
+	mon.Wait()
+}
+
+// TestMonitor_FlappingTreatedAsUnhealthy tests that with a flap policy that treats
+// flapping as unhealthy, the watchdog is notified when flapping starts and is not
+// cancelled by the flapping mount's passing checks.
+func TestMonitor_FlappingTreatedAsUnhealthy(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	canaryPath := filepath.Join(tmpDir, ".health-check")
+	if err := os.WriteFile(canaryPath, []byte("ok"), 0644); err != nil {
+		t.Fatalf("failed to create canary file: %v", err)
+	}
+
+	mount := health.NewMount("flapping-mount", tmpDir, ".health-check", 1)
+	mount.FlapPolicy = health.FlapPolicy{Window: time.Hour, Threshold: 2, Unhealthy: true}
+	checker := health.NewChecker(100 * time.Millisecond)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	checkInterval := 50 * time.Millisecond
+	mon := monitor.New([]*health.Mount{mount}, checker, checkInterval, 1, logger)
+
+	watchdog := &mockWatchdog{}
+	mon.SetWatchdog(watchdog)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mon.Start(ctx)
+
+	is.True(pollForStatus(t, mount, health.StatusHealthy, 5*time.Second, checkInterval))
+	if err := os.Remove(canaryPath); err != nil {
+		t.Fatalf("failed to remove canary file: %v", err)
+	}
+	is.True(pollForStatus(t, mount, health.StatusUnhealthy, 5*time.Second, checkInterval))
+	if err := os.WriteFile(canaryPath, []byte("ok"), 0644); err != nil {
+		t.Fatalf("failed to create canary file: %v", err)
+	}
+	is.True(pollForStatus(t, mount, health.StatusHealthy, 5*time.Second, checkInterval))
+
+	cancel()
+	mon.Wait()
+
+	is.True(mount.IsFlapping())                      // two transitions within the window
+	is.True(watchdog.unhealthyCalls.Load() > 0)      // watchdog notified of unhealthy
+	is.Equal(watchdog.healthyCalls.Load(), int32(0)) // recovery of a flapping mount does not cancel
+}
+
+// TestMonitor_DegradedInterval tests that a failing mount is checked at its
+// degraded interval, so an outage is confirmed without waiting for the base
+// interval between checks.
+func TestMonitor_DegradedInterval(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	// Don't create canary file - every check fails
+
+	mount := health.NewMount("adaptive-mount", tmpDir, ".health-check", 3)
+	mount.Intervals = health.AdaptiveInterval{Degraded: 20 * time.Millisecond}
+	checker := health.NewChecker(100 * time.Millisecond)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	mon := monitor.New([]*health.Mount{mount}, checker, time.Hour, 3, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mon.Start(ctx)
+
+	// Three failures at the base interval would take two hours
+	is.True(pollForStatus(t, mount, health.StatusUnhealthy, 5*time.Second, 10*time.Millisecond))
+
+	cancel()
+	mon.Wait()
+
+	snapshot := mount.Snapshot()
+	is.Equal(snapshot.IntervalMode, health.IntervalDegraded) // degraded interval in use
+	is.Equal(snapshot.Interval, 20*time.Millisecond)         // effective interval reported
+}
+
+// mockMetrics implements MetricsRecorder for testing.
+type mockMetrics struct {
+	checks      atomic.Int32
+	transitions atomic.Int32
+}
+
+func (m *mockMetrics) ObserveCheck(result *health.CheckResult)              { m.checks.Add(1) }
+func (m *mockMetrics) ObserveTransition(transition *health.StateTransition) { m.transitions.Add(1) }
+
+// TestMonitor_SetMetrics tests that check results and transitions are recorded.
+func TestMonitor_SetMetrics(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	mount := health.NewMount("metrics-mount", tmpDir, ".health-check", 1)
+	checker := health.NewChecker(100 * time.Millisecond)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	checkInterval := 50 * time.Millisecond
+	mon := monitor.New([]*health.Mount{mount}, checker, checkInterval, 1, logger)
+
+	recorder := &mockMetrics{}
+	mon.SetMetrics(recorder)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mon.Start(ctx)
+
+	is.True(pollForStatus(t, mount, health.StatusUnhealthy, 5*time.Second, checkInterval))
+
+	cancel()
+	mon.Wait()
+
+	is.True(recorder.checks.Load() > 0)             // checks should be recorded
+	is.Equal(recorder.transitions.Load(), int32(1)) // unknown -> unhealthy recorded once
+}
+
+// slowCheck is a check type that takes delay to complete (or until the read
+// timeout expires) and records how many of its checks ran at the same time.
+type slowCheck struct {
+	delay       time.Duration
+	inFlight    *atomic.Int32
+	maxInFlight *atomic.Int32
+}
+
+var (
+	hangingCheck = slowCheck{delay: time.Hour, inFlight: new(atomic.Int32), maxInFlight: new(atomic.Int32)}
+	sleepyCheck  = slowCheck{delay: 30 * time.Millisecond, inFlight: new(atomic.Int32), maxInFlight: new(atomic.Int32)}
+)
+
+func init() {
+	health.RegisterCheckType("test-hanging", hangingCheck)
+	health.RegisterCheckType("test-sleepy", sleepyCheck)
+}
+
+func (slowCheck) Decode(json.RawMessage) (any, error) { return nil, nil }
+
+func (c slowCheck) Run(ctx context.Context, mountPath string, spec *health.CheckSpec) (health.ProbeResult, error) {
+	n := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+	for {
+		peak := c.maxInFlight.Load()
+		if n <= peak || c.maxInFlight.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	select {
+	case <-time.After(c.delay):
+		return health.ProbeResult{}, nil
+	case <-ctx.Done():
+		return health.ProbeResult{}, ctx.Err()
+	}
+}
+
+// failingCheck is a check type that fails with the error stored in err.
+var failingCheck = errorCheck{err: new(atomic.Pointer[error])}
+
+type errorCheck struct {
+	err *atomic.Pointer[error]
+}
+
+func init() {
+	health.RegisterCheckType("test-failing", failingCheck)
+}
+
+func (errorCheck) Decode(json.RawMessage) (any, error) { return nil, nil }
+
+func (c errorCheck) Run(ctx context.Context, mountPath string, spec *health.CheckSpec) (health.ProbeResult, error) {
+	return health.ProbeResult{}, *c.err.Load()
+}
+
+// TestMonitor_HungMountDoesNotDelayOthers tests that mounts are checked
+// independently, so a mount whose checks hang until the read timeout does not
+// delay the checks of other mounts.
+func TestMonitor_HungMountDoesNotDelayOthers(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	is := is.New(t)
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, ".health-check"), []byte("ok"), 0644); err != nil {
+		t.Fatalf("failed to create canary file: %v", err)
+	}
+
+	// The hung mount comes first, so sequential checks would wait for its timeout
+	hung := health.NewMountWithCheckType("hung", t.TempDir(), "", "test-hanging", 1)
+	fast := health.NewMount("fast", tmpDir, ".health-check", 1)
+	checker := health.NewChecker(2 * time.Second)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	checkInterval := 50 * time.Millisecond
+	mon := monitor.New([]*health.Mount{hung, fast}, checker, checkInterval, 1, logger)
+	mon.SetMaxConcurrent(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mon.Start(ctx)
+
+	is.True(pollForStatus(t, fast, health.StatusHealthy, time.Second, 10*time.Millisecond)) // checked before the hung check times out
+	is.Equal(hung.GetStatus(), health.StatusUnknown)                                        // hung check still in progress
+
+	cancel()
+	mon.Wait()
+}
+
+// TestMonitor_MaxConcurrent tests that no more than the configured number of
+// checks run at the same time.
+func TestMonitor_MaxConcurrent(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	is := is.New(t)
+
+	sleepyCheck.maxInFlight.Store(0)
+	mounts := make([]*health.Mount, 4)
+	for i := range mounts {
+		mounts[i] = health.NewMountWithCheckType("", t.TempDir(), "", "test-sleepy", 3)
+	}
+	checker := health.NewChecker(time.Second)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	checkInterval := 20 * time.Millisecond
+	mon := monitor.New(mounts, checker, checkInterval, 3, logger)
+	mon.SetMaxConcurrent(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mon.Start(ctx)
+
+	for _, mount := range mounts {
+		is.True(pollForStatus(t, mount, health.StatusHealthy, 5*time.Second, checkInterval)) // every mount gets checked
+	}
+
+	cancel()
+	mon.Wait()
+
+	is.Equal(sleepyCheck.maxInFlight.Load(), int32(2)) // checks limited to two at a time
+}
+
+// TestMonitor_CheckNow tests that an on-demand check updates the mount's state
+// and notifies the watchdog like a scheduled check.
+func TestMonitor_CheckNow(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	is := is.New(t)
+
+	// Don't create canary file - the check fails
+	mount := health.NewMount("", t.TempDir(), ".health-check", 1)
+	checker := health.NewChecker(time.Second)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	mon := monitor.New([]*health.Mount{mount}, checker, time.Hour, 1, logger)
+	watchdog := &mockWatchdog{}
+	mon.SetWatchdog(watchdog)
+
+	result, err := mon.CheckNow(context.Background(), mount)
+	is.NoErr(err)                                       // check should run without the monitor loop
+	is.True(!result.Success)                            // missing canary fails
+	is.Equal(mount.GetStatus(), health.StatusUnhealthy) // state updated
+	is.Equal(watchdog.checkedCalls.Load(), int32(1))    // check result reported
+	is.Equal(watchdog.unhealthyCalls.Load(), int32(1))  // transition reported
+
+	_, err = mon.CheckNow(context.Background(), health.NewMount("", t.TempDir(), ".health-check", 1))
+	is.True(errors.Is(err, monitor.ErrUnknownMount)) // only monitored mounts can be checked
+}
+
+// TestMonitor_CheckNow_NotConcurrent tests that on-demand checks never probe a
+// mount while another check of it is running.
+func TestMonitor_CheckNow_NotConcurrent(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	is := is.New(t)
+
+	sleepyCheck.maxInFlight.Store(0)
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", "test-sleepy", 3)
+	checker := health.NewChecker(time.Second)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	mon := monitor.New([]*health.Mount{mount}, checker, time.Hour, 3, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mon.Start(ctx) // The initial scheduled check runs alongside the on-demand checks
+
+	results := make([]*health.CheckResult, 3)
+	errs := make([]error, 3)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = mon.CheckNow(context.Background(), mount)
+		}(i)
+	}
+	wg.Wait()
+
+	cancel()
+	mon.Wait()
+
+	for i := range results {
+		is.NoErr(errs[i])           // check should run
+		is.True(results[i].Success) // sleepy check passes
+	}
+
+	is.Equal(sleepyCheck.maxInFlight.Load(), int32(1)) // one check of the mount at a time
+}
+
+// TestMonitor_CheckNow_Cancelled tests that an on-demand check waiting for a
+// check in progress gives up when its context is cancelled.
+func TestMonitor_CheckNow_Cancelled(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", "test-hanging", 3)
+	checker := health.NewChecker(500 * time.Millisecond)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	mon := monitor.New([]*health.Mount{mount}, checker, time.Hour, 3, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mon.Start(ctx)
+	// Wait for the initial scheduled check to start
+	for hangingCheck.inFlight.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer waitCancel()
+	_, err := mon.CheckNow(waitCtx, mount)
+	is.True(errors.Is(err, context.DeadlineExceeded)) // gave up waiting for the hung check
+
+	cancel()
+	mon.Wait()
+}
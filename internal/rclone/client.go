@@ -0,0 +1,189 @@
+// Package rclone provides a minimal client for the rclone remote control (rc) API.
+//
+// Like the watchdog's Kubernetes client, it uses the standard library HTTP client
+// rather than pulling in rclone as a dependency. Only the handful of rc commands
+// the monitor needs are implemented; see https://rclone.org/rc/ for the full API.
+package rclone
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// maxResponseBodySize limits rc response reads. rc responses for the commands used
+// here are small; core/stats lists at most a few dozen in-flight transfers.
+const maxResponseBodySize = 1 << 20 // 1MB
+
+// defaultHTTPClient is shared by all clients so connections to rc servers are reused.
+var defaultHTTPClient = &http.Client{}
+
+// Client calls rc commands on a single rclone instance.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	username   string
+	password   string
+}
+
+// NewClient creates a client for the rc server at baseURL (e.g. "http://localhost:5572").
+// Username and password enable basic auth (rclone's --rc-user and --rc-pass).
+// Requests are bounded by their context; the client sets no timeout of its own.
+// Clients are cheap to create: they share an underlying HTTP client.
+func NewClient(baseURL, username, password string) *Client {
+	return &Client{
+		httpClient: defaultHTTPClient,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		username:   username,
+		password:   password,
+	}
+}
+
+// Error is an error reported by the rc server.
+type Error struct {
+	Command    string // rc command, e.g. "vfs/stats"
+	StatusCode int    // HTTP status code of the response
+	Message    string // Error message from the rc server
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rclone rc %s: %s (status %d)", e.Command, e.Message, e.StatusCode)
+}
+
+// Call invokes an rc command with the given parameters and decodes the JSON
+// response into out. params and out may be nil.
+func (c *Client) Call(ctx context.Context, command string, params, out any) error {
+	if params == nil {
+		params = struct{}{}
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("marshaling rc %s parameters: %w", command, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/"+command, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("rclone rc %s: %w", command, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return fmt.Errorf("reading rc %s response: %w", command, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// rc reports errors as {"error": "...", "status": 500, ...}
+		var rcErr struct {
+			Error string `json:"error"`
+		}
+		msg := strings.TrimSpace(string(data))
+		if json.Unmarshal(data, &rcErr) == nil && rcErr.Error != "" {
+			msg = rcErr.Error
+		}
+		return &Error{Command: command, StatusCode: resp.StatusCode, Message: msg}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decoding rc %s response: %w", command, err)
+	}
+	return nil
+}
+
+// MountPoint is an active rclone mount as listed by mount/listmounts.
+type MountPoint struct {
+	Fs         string `json:"Fs"`         // Remote being mounted, e.g. "debrid:"
+	MountPoint string `json:"MountPoint"` // Local mount path
+}
+
+// ListMounts returns the mounts managed by the rc server (mount/listmounts).
+func (c *Client) ListMounts(ctx context.Context) ([]MountPoint, error) {
+	var resp struct {
+		MountPoints []MountPoint `json:"mountPoints"`
+	}
+	if err := c.Call(ctx, "mount/listmounts", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.MountPoints, nil
+}
+
+// FindMount returns the mount at mountPoint, or nil if rclone does not list it.
+func FindMount(mounts []MountPoint, mountPoint string) *MountPoint {
+	target := filepath.Clean(mountPoint)
+	for i := range mounts {
+		if filepath.Clean(mounts[i].MountPoint) == target {
+			return &mounts[i]
+		}
+	}
+	return nil
+}
+
+// VFSStats is the subset of vfs/stats the monitor uses.
+type VFSStats struct {
+	Fs        string `json:"fs"`
+	InUse     int    `json:"inUse"`
+	DiskCache *struct {
+		BytesUsed         int64 `json:"bytesUsed"`
+		Files             int64 `json:"files"`
+		ErroredFiles      int   `json:"erroredFiles"`
+		UploadsInProgress int   `json:"uploadsInProgress"`
+		UploadsQueued     int   `json:"uploadsQueued"`
+		OutOfSpace        bool  `json:"outOfSpace"`
+	} `json:"diskCache"` // nil when the VFS cache mode is off
+}
+
+// VFSStats returns statistics for the VFS serving fs (vfs/stats).
+func (c *Client) VFSStats(ctx context.Context, fs string) (*VFSStats, error) {
+	var stats VFSStats
+	if err := c.Call(ctx, "vfs/stats", fsParams(fs), &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// CoreStats is the subset of core/stats the monitor uses.
+type CoreStats struct {
+	Bytes        int64  `json:"bytes"`
+	Errors       int64  `json:"errors"`
+	LastError    string `json:"lastError"`
+	Transfers    int64  `json:"transfers"`
+	Transferring []struct {
+		Name string `json:"name"`
+	} `json:"transferring"`
+}
+
+// CoreStats returns global transfer statistics (core/stats).
+func (c *Client) CoreStats(ctx context.Context) (*CoreStats, error) {
+	var stats CoreStats
+	if err := c.Call(ctx, "core/stats", nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// fsParams returns the parameters selecting a VFS by remote. rc rejects an empty
+// "fs" parameter, so it is omitted when only one VFS is active.
+func fsParams(fs string) map[string]string {
+	params := map[string]string{}
+	if fs != "" {
+		params["fs"] = fs
+	}
+	return params
+}
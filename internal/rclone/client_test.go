@@ -0,0 +1,104 @@
+package rclone_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/rclone"
+	"github.com/cscheib/debrid-mount-monitor/internal/testutil"
+	"github.com/matryer/is"
+)
+
+func TestClient_ListMounts(t *testing.T) {
+	is := is.New(t)
+
+	rc := testutil.NewFakeRC(t)
+	rc.SetResponse("mount/listmounts", map[string]any{
+		"mountPoints": []map[string]any{
+			{"Fs": "debrid:", "MountPoint": "/mnt/debrid"},
+			{"Fs": "other:", "MountPoint": "/mnt/other/"},
+		},
+	})
+
+	client := rclone.NewClient(rc.URL, "", "")
+	mounts, err := client.ListMounts(context.Background())
+	is.NoErr(err)            // listmounts should succeed
+	is.Equal(len(mounts), 2) // both mounts listed
+
+	m := rclone.FindMount(mounts, "/mnt/other")
+	is.True(m != nil)                                        // trailing slash should not matter
+	is.Equal(m.Fs, "other:")                                 // matching remote
+	is.True(rclone.FindMount(mounts, "/mnt/missing") == nil) // unknown mount
+}
+
+func TestClient_Stats(t *testing.T) {
+	is := is.New(t)
+
+	rc := testutil.NewFakeRC(t)
+	rc.SetResponse("vfs/stats", map[string]any{
+		"fs": "debrid:",
+		"diskCache": map[string]any{
+			"bytesUsed":         1024,
+			"files":             3,
+			"uploadsInProgress": 1,
+			"uploadsQueued":     4,
+		},
+	})
+	rc.SetResponse("core/stats", map[string]any{
+		"errors":       2,
+		"lastError":    "read timeout",
+		"transferring": []map[string]any{{"name": "a.mkv"}},
+	})
+
+	client := rclone.NewClient(rc.URL, "", "")
+	vfs, err := client.VFSStats(context.Background(), "debrid:")
+	is.NoErr(err)                                  // vfs/stats should succeed
+	is.True(vfs.DiskCache != nil)                  // cache stats present
+	is.Equal(vfs.DiskCache.BytesUsed, int64(1024)) // cache size
+	is.Equal(vfs.DiskCache.UploadsQueued, 4)       // queued uploads
+
+	core, err := client.CoreStats(context.Background())
+	is.NoErr(err)                            // core/stats should succeed
+	is.Equal(core.Errors, int64(2))          // error count
+	is.Equal(core.LastError, "read timeout") // last error
+	is.Equal(len(core.Transferring), 1)      // in-flight transfers
+
+	calls := rc.Calls()
+	is.Equal(calls[0].Params["fs"], "debrid:") // vfs/stats selects the remote
+}
+
+func TestClient_Error(t *testing.T) {
+	is := is.New(t)
+
+	rc := testutil.NewFakeRC(t)
+	rc.SetError("vfs/stats", http.StatusInternalServerError, "no VFS found with name")
+
+	client := rclone.NewClient(rc.URL, "", "")
+	_, err := client.VFSStats(context.Background(), "debrid:")
+
+	var rcErr *rclone.Error
+	is.True(errors.As(err, &rcErr))                            // should be an rc error
+	is.Equal(rcErr.StatusCode, http.StatusInternalServerError) // status code
+	is.Equal(rcErr.Message, "no VFS found with name")          // rc error message
+}
+
+func TestClient_BasicAuth(t *testing.T) {
+	is := is.New(t)
+
+	var user, pass string
+	var ok bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok = r.BasicAuth()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := rclone.NewClient(srv.URL, "rc", "secret")
+	is.NoErr(client.Call(context.Background(), "rc/noop", nil, nil)) // call should succeed
+	is.True(ok)                                                      // basic auth sent
+	is.Equal(user, "rc")                                             // username
+	is.Equal(pass, "secret")                                         // password
+}
@@ -0,0 +1,266 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scope is the access level granted by an API token.
+type Scope int
+
+const (
+	// ScopeRead allows reading status, metrics and version information.
+	ScopeRead Scope = iota + 1
+	// ScopeAdmin additionally allows endpoints that act on mounts, such as
+	// on-demand checks.
+	ScopeAdmin
+)
+
+// String returns the scope name as used in token files.
+func (s Scope) String() string {
+	switch s {
+	case ScopeRead:
+		return "read"
+	case ScopeAdmin:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
+
+// minTokenLength is the minimum length of an API token, to rule out guessable tokens.
+const minTokenLength = 16
+
+// Authorizer maps bearer tokens to the scope they grant.
+type Authorizer interface {
+	// Authorize returns the scope granted by token, or false if the token is not valid.
+	Authorize(token string) (Scope, bool)
+	// Enabled reports whether any tokens are in effect. Without tokens the API
+	// endpoints respond with 403 Forbidden.
+	Enabled() bool
+}
+
+// tokenStatInterval is the minimum time between checks of the token file for
+// changes, so a burst of requests does not stat the file for each one.
+var tokenStatInterval = 5 * time.Second
+
+// apiToken is a token from a token file. Only its hash is kept, so tokens of
+// any length are compared in constant time.
+type apiToken struct {
+	hash  [sha256.Size]byte
+	scope Scope
+}
+
+// TokenStore authorizes bearer tokens listed in a file and reloads them when
+// the file changes, including when a Kubernetes secret mount is updated.
+//
+// Each non-empty line of the file that does not start with '#' holds a scope
+// ("read" or "admin") and a token, separated by whitespace.
+type TokenStore struct {
+	path   string
+	logger *slog.Logger
+
+	mu        sync.Mutex
+	tokens    []apiToken
+	modTime   time.Time // Modification time of the loaded file
+	size      int64     // Size of the loaded file
+	lastStat  time.Time // When the file was last checked for changes
+	reloadErr error     // Error of the last reload if it failed, so it is logged only once
+}
+
+// LoadTokenStore loads the tokens in the file at path. It returns an error if
+// the file cannot be read or contains an invalid line.
+func LoadTokenStore(path string, logger *slog.Logger) (*TokenStore, error) {
+	s := &TokenStore{path: path, logger: logger}
+	if err := s.reloadLocked(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Authorize returns the scope granted by token. It first reloads the token file
+// if it changed since it was last loaded, checking at most every
+// tokenStatInterval. If the changed file is invalid, the previous tokens stay in
+// effect; if it is missing or unreadable, no token is valid until it loads again.
+func (s *TokenStore) Authorize(token string) (Scope, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshLocked()
+
+	// Compare against every token so the time taken does not reveal which matched
+	hash := sha256.Sum256([]byte(token))
+	var scope Scope
+	for _, t := range s.tokens {
+		if subtle.ConstantTimeCompare(hash[:], t.hash[:]) == 1 && t.scope > scope {
+			scope = t.scope
+		}
+	}
+	return scope, scope != 0
+}
+
+// Enabled reports whether any tokens are in effect, after reloading the token
+// file like Authorize.
+func (s *TokenStore) Enabled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshLocked()
+	return len(s.tokens) > 0
+}
+
+// refreshLocked reloads the token file if it changed, checking at most every
+// tokenStatInterval. Callers must hold s.mu.
+func (s *TokenStore) refreshLocked() {
+	if now := time.Now(); now.Sub(s.lastStat) >= tokenStatInterval {
+		s.lastStat = now
+		s.reloadIfChangedLocked()
+	}
+}
+
+// reloadIfChangedLocked reloads the token file if it changed or disappeared
+// since it was last loaded. A failure is logged when it first occurs or its
+// error changes, not again for every request while the file stays unusable.
+// Callers must hold s.mu.
+func (s *TokenStore) reloadIfChangedLocked() {
+	if info, err := os.Stat(s.path); err == nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return
+	}
+	err := s.reloadLocked()
+	if err != nil && (s.reloadErr == nil || s.reloadErr.Error() != err.Error()) {
+		if len(s.tokens) == 0 {
+			s.logger.Error("failed to reload api tokens, rejecting all tokens", "path", s.path, "error", err)
+		} else {
+			s.logger.Error("failed to reload api tokens, keeping previous tokens", "path", s.path, "error", err)
+		}
+	}
+	s.reloadErr = err
+}
+
+// reloadLocked loads the token file, replacing the current tokens on success.
+// If the file cannot be read, for example because it was deleted to revoke
+// every token, the current tokens are dropped; if it is invalid, they are kept.
+// Callers must hold s.mu (or be constructing s).
+func (s *TokenStore) reloadLocked() error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		s.clearLocked()
+		return err
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		s.clearLocked()
+		return err
+	}
+	tokens, err := parseTokens(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", s.path, err)
+	}
+
+	s.tokens = tokens
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	s.logger.Info("api tokens loaded", "path", s.path, "tokens", len(tokens))
+	return nil
+}
+
+// clearLocked drops the current tokens, so the file is reloaded as soon as it
+// can be read again. Callers must hold s.mu.
+func (s *TokenStore) clearLocked() {
+	s.tokens = nil
+	s.modTime = time.Time{}
+	s.size = 0
+}
+
+// parseTokens parses the content of a token file.
+func parseTokens(data []byte) ([]apiToken, error) {
+	var tokens []apiToken
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected \"<scope> <token>\"", line)
+		}
+		var scope Scope
+		switch fields[0] {
+		case "read":
+			scope = ScopeRead
+		case "admin":
+			scope = ScopeAdmin
+		default:
+			return nil, fmt.Errorf("line %d: scope must be one of: read, admin (got %q)", line, fields[0])
+		}
+		if len(fields[1]) < minTokenLength {
+			return nil, fmt.Errorf("line %d: token must be at least %d characters", line, minTokenLength)
+		}
+		tokens = append(tokens, apiToken{hash: sha256.Sum256([]byte(fields[1])), scope: scope})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// requireScope wraps an API handler so it is only served to requests carrying a
+// bearer token with at least the given scope. Without an Authorizer, read
+// endpoints are open and admin endpoints are disabled; with an Authorizer that
+// has no tokens, all of them are disabled.
+func (s *Server) requireScope(scope Scope, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.auth == nil {
+			if scope == ScopeRead {
+				next(w, r)
+				return
+			}
+			http.Error(w, "admin api disabled: no api tokens configured", http.StatusForbidden)
+			return
+		}
+		if !s.auth.Enabled() {
+			s.logger.Warn("api request rejected", "endpoint", r.URL.Path, "remote_addr", r.RemoteAddr, "reason", "no_tokens")
+			http.Error(w, "api disabled: no api tokens loaded", http.StatusForbidden)
+			return
+		}
+
+		token, ok := bearerToken(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mount-monitor"`)
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		granted, ok := s.auth.Authorize(token)
+		if !ok {
+			s.logger.Warn("api request rejected", "endpoint", r.URL.Path, "remote_addr", r.RemoteAddr, "reason", "invalid_token")
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mount-monitor", error="invalid_token"`)
+			http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+		if granted < scope {
+			s.logger.Warn("api request rejected", "endpoint", r.URL.Path, "remote_addr", r.RemoteAddr, "reason", "insufficient_scope")
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="mount-monitor", error="insufficient_scope", scope=%q`, scope.String()))
+			http.Error(w, fmt.Sprintf("token lacks %s scope", scope), http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// bearerToken returns the token of the request's "Authorization: Bearer" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
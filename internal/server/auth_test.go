@@ -0,0 +1,290 @@
+package server_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/health"
+	"github.com/cscheib/debrid-mount-monitor/internal/server"
+	"github.com/matryer/is"
+)
+
+// writeTokens writes a token file and moves its modification time forward, so
+// a rewrite is noticed even within the file system's timestamp granularity.
+func writeTokens(t *testing.T, path, content string, age time.Duration) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	modTime := time.Now().Add(-age)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("failed to set token file time: %v", err)
+	}
+}
+
+func TestTokenStore_Authorize(t *testing.T) {
+	is := is.New(t)
+
+	path := filepath.Join(t.TempDir(), "tokens")
+	writeTokens(t, path, "# scope token\nread "+readToken+"\n\nadmin "+adminToken+"\n", time.Minute)
+
+	store, err := server.LoadTokenStore(path, testLogger())
+	is.NoErr(err) // should load token file
+
+	scope, ok := store.Authorize(readToken)
+	is.True(ok)                       // read token accepted
+	is.Equal(scope, server.ScopeRead) // read scope
+	scope, ok = store.Authorize(adminToken)
+	is.True(ok)                        // admin token accepted
+	is.Equal(scope, server.ScopeAdmin) // admin scope
+	_, ok = store.Authorize("not-a-valid-token-at-all")
+	is.True(!ok) // unknown token rejected
+	_, ok = store.Authorize("")
+	is.True(!ok) // empty token rejected
+}
+
+func TestTokenStore_ReloadsOnChange(t *testing.T) {
+	is := is.New(t)
+	defer server.SetTokenStatIntervalForTesting(0)()
+
+	path := filepath.Join(t.TempDir(), "tokens")
+	writeTokens(t, path, "admin "+adminToken+"\n", time.Hour)
+
+	store, err := server.LoadTokenStore(path, testLogger())
+	is.NoErr(err) // should load token file
+
+	// Rotate the admin token out and a read token in
+	writeTokens(t, path, "read "+readToken+"\n", time.Minute)
+
+	_, ok := store.Authorize(adminToken)
+	is.True(!ok) // rotated token rejected
+	scope, ok := store.Authorize(readToken)
+	is.True(ok)                       // new token accepted
+	is.Equal(scope, server.ScopeRead) // with its scope
+
+	// A broken file keeps the previous tokens in effect
+	writeTokens(t, path, "superuser "+adminToken+"\n", 0)
+
+	_, ok = store.Authorize(readToken)
+	is.True(ok) // previous tokens kept
+	_, ok = store.Authorize(adminToken)
+	is.True(!ok) // invalid line not loaded
+}
+
+// TestTokenStore_ThrottlesReload tests that the token file is checked for
+// changes at most once per stat interval.
+func TestTokenStore_ThrottlesReload(t *testing.T) {
+	is := is.New(t)
+	defer server.SetTokenStatIntervalForTesting(time.Hour)()
+
+	path := filepath.Join(t.TempDir(), "tokens")
+	writeTokens(t, path, "admin "+adminToken+"\n", time.Hour)
+
+	store, err := server.LoadTokenStore(path, testLogger())
+	is.NoErr(err) // should load token file
+
+	_, ok := store.Authorize(adminToken)
+	is.True(ok) // first request checks the file
+
+	writeTokens(t, path, "read "+readToken+"\n", time.Minute)
+
+	_, ok = store.Authorize(readToken)
+	is.True(!ok) // change not noticed within the stat interval
+	_, ok = store.Authorize(adminToken)
+	is.True(ok) // previous tokens still in effect
+}
+
+// TestTokenStore_MissingFile tests that a token file that disappears revokes
+// every token, and is reported once, not on every request.
+func TestTokenStore_MissingFile(t *testing.T) {
+	is := is.New(t)
+	defer server.SetTokenStatIntervalForTesting(0)()
+
+	path := filepath.Join(t.TempDir(), "tokens")
+	writeTokens(t, path, "admin "+adminToken+"\n", time.Hour)
+
+	var logs bytes.Buffer
+	store, err := server.LoadTokenStore(path, slog.New(slog.NewTextHandler(&logs, nil)))
+	is.NoErr(err) // should load token file
+
+	is.NoErr(os.Remove(path)) // token file removed
+	for i := 0; i < 3; i++ {
+		_, ok := store.Authorize(adminToken)
+		is.True(!ok) // previous tokens revoked
+	}
+	is.True(!store.Enabled())                                                                      // no tokens in effect
+	is.Equal(strings.Count(logs.String(), "failed to reload api tokens, rejecting all tokens"), 1) // logged once
+
+	writeTokens(t, path, "read "+readToken+"\n", time.Minute)
+	_, ok := store.Authorize(readToken)
+	is.True(ok)              // restored file loaded
+	is.True(store.Enabled()) // tokens in effect again
+
+	is.NoErr(os.Remove(path)) // removed again
+	store.Authorize(readToken)
+	is.Equal(strings.Count(logs.String(), "failed to reload api tokens"), 2) // logged again after recovering
+}
+
+// TestTokenStore_InvalidFile tests that a token file that becomes invalid keeps
+// the previous tokens in effect, and is reported once, not on every request.
+func TestTokenStore_InvalidFile(t *testing.T) {
+	is := is.New(t)
+	defer server.SetTokenStatIntervalForTesting(0)()
+
+	path := filepath.Join(t.TempDir(), "tokens")
+	writeTokens(t, path, "admin "+adminToken+"\n", time.Hour)
+
+	var logs bytes.Buffer
+	store, err := server.LoadTokenStore(path, slog.New(slog.NewTextHandler(&logs, nil)))
+	is.NoErr(err) // should load token file
+
+	writeTokens(t, path, "superuser "+readToken+"\n", time.Minute) // half-written edit
+	for i := 0; i < 3; i++ {
+		_, ok := store.Authorize(adminToken)
+		is.True(ok) // previous tokens kept
+	}
+	_, ok := store.Authorize(readToken)
+	is.True(!ok)                                                                                      // invalid line not loaded
+	is.True(store.Enabled())                                                                          // tokens still in effect
+	is.Equal(strings.Count(logs.String(), "failed to reload api tokens, keeping previous tokens"), 1) // logged once
+}
+
+func TestLoadTokenStore_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"unknown scope", "write " + adminToken},
+		{"missing token", "admin"},
+		{"extra field", "admin " + adminToken + " extra"},
+		{"short token", "admin secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			path := filepath.Join(t.TempDir(), "tokens")
+			writeTokens(t, path, tt.content, 0)
+
+			_, err := server.LoadTokenStore(path, testLogger())
+			is.True(err != nil) // invalid token file should error
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		is := is.New(t)
+		_, err := server.LoadTokenStore(filepath.Join(t.TempDir(), "tokens"), testLogger())
+		is.True(err != nil) // missing token file should error
+	})
+}
+
+// TestAuth_Endpoints tests which endpoints require which scope once
+// authentication is configured.
+func TestAuth_Endpoints(t *testing.T) {
+	mount := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	srv.SetMetrics(stubMetrics{})
+	srv.SetChecker(&stubChecker{}, time.Second)
+	srv.SetAuth(stubAuth{})
+	handler := createServerHandler(srv)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		token  string
+		want   int
+	}{
+		{"liveness open", http.MethodGet, "/healthz/live", "", http.StatusOK},
+		{"readiness open", http.MethodGet, "/healthz/ready", "", http.StatusServiceUnavailable},
+		{"status open", http.MethodGet, "/healthz/status", "", http.StatusServiceUnavailable},
+		{"version without token", http.MethodGet, "/version", "", http.StatusUnauthorized},
+		{"version with read token", http.MethodGet, "/version", readToken, http.StatusOK},
+		{"metrics with invalid token", http.MethodGet, "/metrics", "not-a-valid-token-at-all", http.StatusUnauthorized},
+		{"metrics with read token", http.MethodGet, "/metrics", readToken, http.StatusOK},
+		{"metrics with admin token", http.MethodGet, "/metrics", adminToken, http.StatusOK},
+		{"check without token", http.MethodPost, "/api/v1/mounts/movies/check", "", http.StatusUnauthorized},
+		{"check with read token", http.MethodPost, "/api/v1/mounts/movies/check", readToken, http.StatusForbidden},
+		{"check with admin token", http.MethodPost, "/api/v1/mounts/movies/check", adminToken, http.StatusOK},
+		{"check all with read token", http.MethodPost, "/api/v1/mounts/check", readToken, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			is.Equal(rec.Code, tt.want) // status code
+			if tt.want == http.StatusUnauthorized {
+				is.True(rec.Header().Get("WWW-Authenticate") != "") // challenge sent
+			}
+		})
+	}
+}
+
+// TestAuth_TokenFileRemoved tests that removing the token file disables the API
+// endpoints instead of leaving the previous tokens in effect.
+func TestAuth_TokenFileRemoved(t *testing.T) {
+	is := is.New(t)
+	defer server.SetTokenStatIntervalForTesting(0)()
+
+	path := filepath.Join(t.TempDir(), "tokens")
+	writeTokens(t, path, "read "+readToken+"\nadmin "+adminToken+"\n", time.Hour)
+	store, err := server.LoadTokenStore(path, testLogger())
+	is.NoErr(err) // should load token file
+
+	mount := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	srv.SetChecker(&stubChecker{}, time.Second)
+	srv.SetAuth(store)
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, adminRequest(http.MethodPost, "/api/v1/mounts/movies/check"))
+	is.Equal(rec.Code, http.StatusOK) // admin token accepted
+
+	is.NoErr(os.Remove(path)) // token file removed
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, adminRequest(http.MethodPost, "/api/v1/mounts/movies/check"))
+	is.Equal(rec.Code, http.StatusForbidden) // admin api disabled
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	req.Header.Set("Authorization", "Bearer "+readToken)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	is.Equal(rec.Code, http.StatusForbidden) // read api disabled too
+}
+
+// TestAuth_AdminDisabledWithoutTokens tests that the mount API is not served
+// unless authentication is configured.
+func TestAuth_AdminDisabledWithoutTokens(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	srv.SetChecker(&stubChecker{}, time.Second)
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, adminRequest(http.MethodPost, "/api/v1/mounts/movies/check"))
+	is.Equal(rec.Code, http.StatusForbidden) // admin api disabled
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+	is.Equal(rec.Code, http.StatusOK) // read endpoints stay open
+}
@@ -4,14 +4,28 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cscheib/debrid-mount-monitor/internal/health"
 )
 
+// MetricsExporter renders metrics in Prometheus text exposition format.
+type MetricsExporter interface {
+	WriteMetrics(w io.Writer) error
+}
+
+// MountChecker runs checks of mounts on demand.
+type MountChecker interface {
+	CheckNow(ctx context.Context, mount *health.Mount) (*health.CheckResult, error)
+}
+
 // Server provides HTTP endpoints for health probes.
 type Server struct {
 	mounts  []*health.Mount
@@ -19,6 +33,11 @@ type Server struct {
 	version string
 	logger  *slog.Logger
 	server  *http.Server
+	metrics MetricsExporter
+	checker MountChecker
+	auth    Authorizer
+
+	checkTimeout time.Duration // Read timeout of the checks run by checker
 }
 
 // New creates a new Server instance.
@@ -30,17 +49,20 @@ func New(mounts []*health.Mount, port int, version string, logger *slog.Logger)
 		logger:  logger,
 	}
 
+	// Probes stay open for the kubelet; everything else is subject to SetAuth
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz/live", s.handleLiveness)
 	mux.HandleFunc("/healthz/ready", s.handleReadiness)
 	mux.HandleFunc("/healthz/status", s.handleStatus)
-	mux.HandleFunc("/version", s.handleVersion)
+	mux.HandleFunc("/version", s.requireScope(ScopeRead, s.handleVersion))
+	mux.HandleFunc("/metrics", s.requireScope(ScopeRead, s.handleMetrics))
+	mux.HandleFunc(mountsAPIPrefix, s.requireScope(ScopeAdmin, s.handleCheck))
 
 	s.server = &http.Server{
 		Addr:              fmt.Sprintf(":%d", port),
 		Handler:           mux,
 		ReadTimeout:       15 * time.Second,
-		WriteTimeout:      15 * time.Second,
+		WriteTimeout:      writeTimeout,
 		ReadHeaderTimeout: 10 * time.Second,
 		IdleTimeout:       60 * time.Second,
 	}
@@ -48,6 +70,28 @@ func New(mounts []*health.Mount, port int, version string, logger *slog.Logger)
 	return s
 }
 
+// SetMetrics sets the exporter served on /metrics.
+// If no exporter is set, /metrics responds with 404 Not Found.
+func (s *Server) SetMetrics(m MetricsExporter) {
+	s.metrics = m
+}
+
+// SetChecker sets the checker that runs on-demand checks, and the read timeout
+// of its checks, which extends the time the check endpoints may take to respond.
+// If no checker is set, the check endpoints respond with 404 Not Found.
+func (s *Server) SetChecker(c MountChecker, readTimeout time.Duration) {
+	s.checker = c
+	s.checkTimeout = readTimeout
+}
+
+// SetAuth sets the authorizer for bearer tokens. Once set, /version and /metrics
+// require a token with read scope and the mount API requires admin scope. If no
+// authorizer is set, /version and /metrics are open and the mount API responds
+// with 403 Forbidden. The /healthz probes never require a token.
+func (s *Server) SetAuth(a Authorizer) {
+	s.auth = a
+}
+
 // Start begins listening for HTTP requests.
 func (s *Server) Start() error {
 	go func() {
@@ -70,7 +114,7 @@ func (s *Server) Handler() http.Handler {
 
 // handleLiveness responds to liveness probe requests.
 // Returns 200 OK if no mount is UNHEALTHY (past failure threshold).
-// Per spec: HEALTHY, DEGRADED, and UNKNOWN states return 200; only UNHEALTHY returns 503.
+// Per spec: HEALTHY, DEGRADED, RECOVERING, and UNKNOWN states return 200; only UNHEALTHY returns 503.
 func (s *Server) handleLiveness(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -105,7 +149,9 @@ func (s *Server) handleLiveness(w http.ResponseWriter, r *http.Request) {
 
 // handleReadiness responds to readiness probe requests.
 // Returns 200 OK only if ALL mounts are HEALTHY, 503 Service Unavailable otherwise.
-// Per spec: DEGRADED, UNHEALTHY, and UNKNOWN states all return 503.
+// Per spec: DEGRADED, UNHEALTHY, RECOVERING, and UNKNOWN states all return 503,
+// so a flapping mount stays out of service until it passes recoveryThreshold checks.
+// Mounts that flap detection considers flapping are not ready either.
 func (s *Server) handleReadiness(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -114,9 +160,7 @@ func (s *Server) handleReadiness(w http.ResponseWriter, r *http.Request) {
 
 	allHealthy := true
 	for _, mount := range s.mounts {
-		status := mount.GetStatus()
-		// Only HEALTHY state is considered ready - DEGRADED, UNHEALTHY, and UNKNOWN all fail
-		if status != health.StatusHealthy {
+		if !mountReady(mount) {
 			allHealthy = false
 			break
 		}
@@ -137,14 +181,88 @@ func (s *Server) handleReadiness(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// mountReady reports whether a mount counts as ready.
+// Only HEALTHY state is considered ready - DEGRADED, UNHEALTHY, RECOVERING, and UNKNOWN
+// all fail - and only while the mount is not flapping.
+func mountReady(mount *health.Mount) bool {
+	return mount.GetStatus() == health.StatusHealthy && !mount.IsFlapping()
+}
+
 // MountStatusResponse represents the status of a single mount.
 type MountStatusResponse struct {
-	Name         string `json:"name,omitempty"`
-	Path         string `json:"path"`
-	Status       string `json:"status"`
-	LastCheck    string `json:"last_check,omitempty"`
-	FailureCount int    `json:"failure_count"`
-	LastError    string `json:"last_error,omitempty"`
+	Name          string                 `json:"name,omitempty"`
+	Path          string                 `json:"path"`
+	Status        string                 `json:"status"`
+	LastCheck     string                 `json:"last_check,omitempty"`
+	FailureCount  int                    `json:"failure_count"`
+	SuccessCount  int                    `json:"success_count,omitempty"`
+	Flapping      bool                   `json:"flapping,omitempty"`
+	Transitions   int                    `json:"recent_transitions,omitempty"`
+	CheckInterval string                 `json:"check_interval,omitempty"` // Effective interval until the next check
+	IntervalMode  string                 `json:"interval_mode,omitempty"`  // "normal", "degraded" or "stable"
+	IntervalSince string                 `json:"interval_since,omitempty"` // When the mount switched to interval_mode
+	FailureWindow *FailureWindowResponse `json:"failure_window,omitempty"`
+	Slow          bool                   `json:"slow,omitempty"`
+	Latency       *LatencyResponse       `json:"latency,omitempty"`
+	StuckProbes   int                    `json:"stuck_probes,omitempty"`
+	StuckProbeAge string                 `json:"stuck_probe_age,omitempty"` // How long the oldest stuck probe has been blocked
+	LastError     string                 `json:"last_error,omitempty"`
+	FailureReason string                 `json:"failure_reason,omitempty"`
+	MountSource   string                 `json:"mount_source,omitempty"`
+	FSType        string                 `json:"fs_type,omitempty"`
+	MountOptions  string                 `json:"mount_options,omitempty"`
+	CheckMode     string                 `json:"check_mode,omitempty"`
+	Checks        []SubCheckResponse     `json:"checks,omitempty"`
+	Dependencies  []DependencyResponse   `json:"dependencies,omitempty"`
+	Rclone        *RcloneResponse        `json:"rclone,omitempty"`
+}
+
+// FailureWindowResponse represents the recent check results of a mount that uses
+// sliding-window failure counting.
+type FailureWindowResponse struct {
+	Size      int      `json:"size"`
+	Threshold int      `json:"failure_threshold"`
+	Failures  int      `json:"failures"`
+	Results   []string `json:"results"` // "passed" or "failed", oldest first
+}
+
+// LatencyResponse represents rolling percentiles of a mount's recent check durations.
+type LatencyResponse struct {
+	P50     string `json:"p50"`
+	P95     string `json:"p95"`
+	Samples int    `json:"samples"`
+}
+
+// RcloneResponse represents the rclone statistics gathered by an rclone-rc check.
+// Transfer and error counts cover the whole rclone instance, not just this mount.
+type RcloneResponse struct {
+	Remote            string `json:"remote"`
+	CacheBytes        int64  `json:"cache_bytes"`
+	CacheFiles        int64  `json:"cache_files"`
+	UploadsInProgress int    `json:"uploads_in_progress"`
+	UploadsQueued     int    `json:"uploads_queued"`
+	ErroredFiles      int    `json:"errored_files"`
+	TransfersInFlight int    `json:"transfers_in_flight"`
+	Errors            int64  `json:"errors"`
+	LastError         string `json:"last_error,omitempty"`
+}
+
+// DependencyResponse represents the last result of a check that probes a dependency
+// of the mount, such as the WebDAV server behind it.
+type DependencyResponse struct {
+	CheckType string `json:"check_type"`
+	Target    string `json:"target"`
+	Status    string `json:"status"`
+	Latency   string `json:"latency,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SubCheckResponse represents the last result of one check of a composite mount check.
+type SubCheckResponse struct {
+	CheckType string `json:"check_type"`
+	Status    string `json:"status"`
+	Duration  string `json:"duration,omitempty"`
+	Error     string `json:"error,omitempty"`
 }
 
 // StatusResponse represents the overall status response.
@@ -178,6 +296,90 @@ func (s *Server) buildProbeResponse(isHealthy bool) StatusResponse {
 			LastCheck:    lastCheck,
 			FailureCount: snapshot.FailureCount,
 			LastError:    snapshot.LastError,
+			MountSource:  snapshot.MountSource,
+			FSType:       snapshot.FSType,
+			MountOptions: snapshot.MountOptions,
+			CheckMode:    snapshot.CheckMode,
+			Flapping:     snapshot.Flapping,
+			Transitions:  snapshot.Transitions,
+			Slow:         snapshot.Slow,
+		}
+		if snapshot.Interval > 0 {
+			mountStatuses[i].CheckInterval = snapshot.Interval.String()
+			mountStatuses[i].IntervalMode = snapshot.IntervalMode.String()
+			if !snapshot.IntervalSince.IsZero() {
+				mountStatuses[i].IntervalSince = snapshot.IntervalSince.Format(time.RFC3339)
+			}
+		}
+		if snapshot.StuckProbes > 0 {
+			mountStatuses[i].StuckProbes = snapshot.StuckProbes
+			mountStatuses[i].StuckProbeAge = time.Since(snapshot.StuckSince).Round(time.Second).String()
+		}
+		if snapshot.Latency.Samples > 0 {
+			mountStatuses[i].Latency = &LatencyResponse{
+				P50:     snapshot.Latency.P50.String(),
+				P95:     snapshot.Latency.P95.String(),
+				Samples: snapshot.Latency.Samples,
+			}
+		}
+		if snapshot.WindowSize > 0 {
+			window := &FailureWindowResponse{
+				Size:      snapshot.WindowSize,
+				Threshold: snapshot.WindowLimit,
+				Results:   make([]string, len(snapshot.Window)),
+			}
+			for j, passed := range snapshot.Window {
+				window.Results[j] = "passed"
+				if !passed {
+					window.Results[j] = "failed"
+					window.Failures++
+				}
+			}
+			mountStatuses[i].FailureWindow = window
+		}
+		if snapshot.Status == health.StatusRecovering {
+			mountStatuses[i].SuccessCount = snapshot.SuccessCount
+		}
+		if snapshot.LastReason != health.ReasonNone {
+			mountStatuses[i].FailureReason = snapshot.LastReason.String()
+		}
+		for _, sub := range snapshot.Checks {
+			duration := ""
+			if sub.Status != "skipped" {
+				duration = sub.Duration.String()
+			}
+			mountStatuses[i].Checks = append(mountStatuses[i].Checks, SubCheckResponse{
+				CheckType: sub.CheckType,
+				Status:    sub.Status,
+				Duration:  duration,
+				Error:     sub.Error,
+			})
+		}
+		for _, dep := range snapshot.Dependencies {
+			latency := ""
+			if dep.Status != "unknown" {
+				latency = dep.Duration.String()
+			}
+			mountStatuses[i].Dependencies = append(mountStatuses[i].Dependencies, DependencyResponse{
+				CheckType: dep.CheckType,
+				Target:    dep.Target,
+				Status:    dep.Status,
+				Latency:   latency,
+				Error:     dep.Error,
+			})
+		}
+		if rc := snapshot.Rclone; rc != nil {
+			mountStatuses[i].Rclone = &RcloneResponse{
+				Remote:            rc.Remote,
+				CacheBytes:        rc.CacheBytes,
+				CacheFiles:        rc.CacheFiles,
+				UploadsInProgress: rc.UploadsInProgress,
+				UploadsQueued:     rc.UploadsQueued,
+				ErroredFiles:      rc.ErroredFiles,
+				TransfersInFlight: rc.Transfers,
+				Errors:            rc.Errors,
+				LastError:         rc.LastError,
+			}
 		}
 	}
 
@@ -199,7 +401,7 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 	// Check if all mounts are healthy (same logic as readiness)
 	overallHealthy := true
 	for _, mount := range s.mounts {
-		if mount.GetStatus() != health.StatusHealthy {
+		if !mountReady(mount) {
 			overallHealthy = false
 			break
 		}
@@ -239,3 +441,185 @@ func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
 		s.logger.Error("failed to encode version response", "error", err)
 	}
 }
+
+// handleMetrics responds with metrics in Prometheus text exposition format.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.metrics == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if err := s.metrics.WriteMetrics(w); err != nil {
+		s.logger.Error("failed to write metrics response", "error", err)
+	}
+}
+
+// mountsAPIPrefix is the path prefix of the mount API endpoints.
+const mountsAPIPrefix = "/api/v1/mounts/"
+
+// writeTimeout bounds the time from reading a request to writing its response.
+const writeTimeout = 15 * time.Second
+
+// checkWaitTimeout bounds how long an on-demand check waits for a check of the
+// mount that is already running. The check itself then runs up to the read
+// timeout, and the write deadline of the response is extended to fit both.
+const checkWaitTimeout = 5 * time.Second
+
+// checkWriteMargin is added to the write deadline of an on-demand check for
+// killing an isolated probe helper, recording the result and writing it.
+const checkWriteMargin = 5 * time.Second
+
+// CheckResponse represents the result of an on-demand check of a mount.
+type CheckResponse struct {
+	Name          string             `json:"name,omitempty"`
+	Path          string             `json:"path"`
+	Success       bool               `json:"success"`
+	Status        string             `json:"status"`              // Mount status after the check
+	Timestamp     string             `json:"timestamp,omitempty"` // Empty if the check did not run
+	Duration      string             `json:"duration,omitempty"`
+	Error         string             `json:"error,omitempty"`
+	FailureReason string             `json:"failure_reason,omitempty"`
+	Checks        []SubCheckResponse `json:"checks,omitempty"`
+}
+
+// CheckAllResponse represents the results of an on-demand check of every mount.
+// A mount whose check did not run has a result with only its name, path, status
+// and error.
+type CheckAllResponse struct {
+	Results []CheckResponse `json:"results"`
+}
+
+// handleCheck runs on-demand checks: POST /api/v1/mounts/{name}/check checks the
+// named mount, POST /api/v1/mounts/check checks every mount. The results are
+// recorded like scheduled checks and returned once the checks have finished.
+// Mounts are checked in parallel, and a mount whose check is already running
+// is waited for at most checkWaitTimeout, so checking every mount takes no
+// longer than checking one.
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, mountsAPIPrefix)
+	checkAll := rest == health.CheckAllName
+	name, ok := strings.CutSuffix(rest, "/check")
+	if !checkAll && (!ok || name == "") {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.checker == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	mounts := s.mounts
+	if !checkAll {
+		mounts = nil
+		for _, mount := range s.mounts {
+			if mount.GetName() == name {
+				mounts = append(mounts, mount)
+				break
+			}
+		}
+		if mounts == nil {
+			http.Error(w, fmt.Sprintf("mount %q not found", name), http.StatusNotFound)
+			return
+		}
+	}
+
+	s.logger.Info("on-demand check requested", "endpoint", r.URL.Path, "mounts", len(mounts))
+
+	// A check may take longer than writeTimeout allows for other responses
+	deadline := time.Now().Add(checkWaitTimeout + s.checkTimeout + checkWriteMargin)
+	if err := http.NewResponseController(w).SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		s.logger.Warn("failed to extend write deadline for on-demand check", "endpoint", r.URL.Path, "error", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), checkWaitTimeout)
+	defer cancel()
+
+	// Check mounts in parallel so one hung mount does not hold up the others
+	responses := make([]CheckResponse, len(mounts))
+	errs := make([]error, len(mounts))
+	var wg sync.WaitGroup
+	for i, mount := range mounts {
+		wg.Add(1)
+		go func(i int, mount *health.Mount) {
+			defer wg.Done()
+			result, err := s.checker.CheckNow(ctx, mount)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			responses[i] = buildCheckResponse(mount, result)
+		}(i, mount)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		s.logger.Warn("on-demand check not run", "endpoint", r.URL.Path, "mount", mounts[i].GetName(), "path", mounts[i].Path, "error", err)
+		if !checkAll {
+			http.Error(w, "check not run: "+err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		// The other mounts were checked, so report their results and this error
+		responses[i] = CheckResponse{
+			Name:   mounts[i].GetName(),
+			Path:   mounts[i].Path,
+			Status: mounts[i].GetStatus().String(),
+			Error:  "check not run: " + err.Error(),
+		}
+	}
+
+	var response any = CheckAllResponse{Results: responses}
+	if !checkAll {
+		response = responses[0]
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.logger.Error("failed to encode check response", "error", err)
+	}
+}
+
+// buildCheckResponse creates the response for an on-demand check of a mount.
+func buildCheckResponse(mount *health.Mount, result *health.CheckResult) CheckResponse {
+	response := CheckResponse{
+		Name:      mount.Name,
+		Path:      mount.Path,
+		Success:   result.Success,
+		Status:    mount.GetStatus().String(),
+		Timestamp: result.Timestamp.Format(time.RFC3339),
+		Duration:  result.Duration.String(),
+	}
+	if result.Error != nil {
+		response.Error = result.Error.Error()
+		response.FailureReason = result.Reason.String()
+	}
+	for _, sub := range result.SubResults {
+		duration := ""
+		if !sub.Skipped {
+			duration = sub.Duration.String()
+		}
+		errMsg := ""
+		if sub.Error != nil {
+			errMsg = sub.Error.Error()
+		}
+		response.Checks = append(response.Checks, SubCheckResponse{
+			CheckType: sub.CheckType,
+			Status:    sub.Status(),
+			Duration:  duration,
+			Error:     errMsg,
+		})
+	}
+	return response
+}
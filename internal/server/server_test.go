@@ -3,10 +3,12 @@ package server_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -495,3 +497,615 @@ func TestServer_StartAndShutdown(t *testing.T) {
 	err = srv.Shutdown(ctx)
 	is.NoErr(err) // should shutdown without error
 }
+
+// stubMetrics implements server.MetricsExporter for testing.
+type stubMetrics struct{}
+
+func (stubMetrics) WriteMetrics(w io.Writer) error {
+	_, err := io.WriteString(w, "mount_monitor_mount_status 1\n")
+	return err
+}
+
+// TestMetricsEndpoint tests /metrics serves the configured exporter.
+func TestMetricsEndpoint(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 3)
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	handler := createServerHandler(srv)
+
+	// Without an exporter the endpoint is not available
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	is.Equal(rec.Code, http.StatusNotFound) // should return 404 without exporter
+
+	srv.SetMetrics(stubMetrics{})
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	is.Equal(rec.Code, http.StatusOK)                                                      // should return 200
+	is.Equal(rec.Header().Get("Content-Type"), "text/plain; version=0.0.4; charset=utf-8") // exposition content type
+	is.Equal(rec.Body.String(), "mount_monitor_mount_status 1\n")                          // exporter output
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	is.Equal(rec.Code, http.StatusMethodNotAllowed) // should reject non-GET
+}
+
+// TestStatusEndpoint_IncludesMountInfo tests that mount table details are reported when verified.
+func TestStatusEndpoint_IncludesMountInfo(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("movies", "/mnt/movies", "", health.CheckTypeMountinfo, 3)
+	mount.UpdateState(&health.CheckResult{
+		Mount:     mount,
+		Timestamp: time.Now(),
+		Success:   true,
+		MountInfo: &health.MountInfo{MountPoint: "/mnt/movies", FSType: "fuse.rclone", Source: "debrid:", Options: "rw,relatime"},
+	}, 3)
+
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/status", nil))
+
+	var response server.StatusResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response)) // should parse response
+
+	is.Equal(response.Mounts[0].MountSource, "debrid:")      // mount source
+	is.Equal(response.Mounts[0].FSType, "fuse.rclone")       // filesystem type
+	is.Equal(response.Mounts[0].MountOptions, "rw,relatime") // mount options
+}
+
+// TestStatusEndpoint_IncludesCompositeChecks tests that per-check results are reported for composite mounts.
+func TestStatusEndpoint_IncludesCompositeChecks(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	mount.Checks = []health.CheckSpec{
+		health.NewCheckSpec("/mnt/movies", ".health-check", health.CheckTypeCanary),
+		health.NewCheckSpec("/mnt/movies", "", health.CheckTypeListing),
+	}
+	mount.UpdateState(&health.CheckResult{
+		Mount:     mount,
+		Timestamp: time.Now(),
+		Success:   false,
+		Error:     errors.New("canary check: file does not exist"),
+		SubResults: []health.SubCheckResult{
+			{CheckType: health.CheckTypeCanary, Duration: time.Millisecond, Error: errors.New("file does not exist")},
+			{CheckType: health.CheckTypeListing, Skipped: true},
+		},
+	}, 3)
+
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/status", nil))
+
+	var response server.StatusResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response)) // should parse response
+
+	checks := response.Mounts[0].Checks
+	is.Equal(response.Mounts[0].CheckMode, "all")    // default check mode
+	is.Equal(len(checks), 2)                         // one entry per check
+	is.Equal(checks[0].CheckType, "canary")          // first check type
+	is.Equal(checks[0].Status, "failed")             // first check failed
+	is.Equal(checks[0].Error, "file does not exist") // first check error
+	is.Equal(checks[1].Status, "skipped")            // second check skipped
+	is.Equal(checks[1].Duration, "")                 // skipped checks have no duration
+}
+
+// TestStatusEndpoint_IncludesDependencies tests that dependency check results are reported separately.
+func TestStatusEndpoint_IncludesDependencies(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("zurg", "/mnt/zurg", "", health.CheckTypeHTTP, 3)
+	mount.UpdateState(&health.CheckResult{
+		Mount:     mount,
+		Timestamp: time.Now(),
+		Success:   false,
+		Error:     errors.New("unexpected http status: GET http://zurg:9999/dav/ returned 502 Bad Gateway"),
+		Dependencies: []health.DependencyResult{{
+			SubCheckResult: health.SubCheckResult{
+				CheckType: health.CheckTypeHTTP,
+				Duration:  15 * time.Millisecond,
+				Error:     errors.New("unexpected http status"),
+			},
+			Target: "http://zurg:9999/dav/",
+		}},
+	}, 3)
+
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/status", nil))
+
+	var response server.StatusResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response)) // should parse response
+
+	deps := response.Mounts[0].Dependencies
+	is.Equal(len(deps), 1)                            // one dependency
+	is.Equal(deps[0].CheckType, "http")               // dependency check type
+	is.Equal(deps[0].Target, "http://zurg:9999/dav/") // dependency target
+	is.Equal(deps[0].Status, "down")                  // dependency is down
+	is.Equal(deps[0].Latency, "15ms")                 // dependency latency
+	is.Equal(deps[0].Error, "unexpected http status") // dependency error
+}
+
+func TestStatusEndpoint_IncludesRcloneStats(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMountWithCheckType("debrid", "/mnt/debrid", "", health.CheckTypeRcloneRC, 3)
+	mount.UpdateState(&health.CheckResult{
+		Mount:     mount,
+		Timestamp: time.Now(),
+		Success:   true,
+		Rclone: &health.RcloneStats{
+			Remote:        "debrid:",
+			CacheBytes:    4096,
+			CacheFiles:    2,
+			UploadsQueued: 1,
+			Transfers:     3,
+			Errors:        5,
+			LastError:     "read timeout",
+		},
+	}, 3)
+
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/status", nil))
+
+	var response server.StatusResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response)) // should parse response
+
+	rc := response.Mounts[0].Rclone
+	is.True(rc != nil)                     // rclone stats should be present
+	is.Equal(rc.Remote, "debrid:")         // remote
+	is.Equal(rc.CacheBytes, int64(4096))   // cache size
+	is.Equal(rc.UploadsQueued, 1)          // queued uploads
+	is.Equal(rc.TransfersInFlight, 3)      // in-flight transfers
+	is.Equal(rc.Errors, int64(5))          // error count
+	is.Equal(rc.LastError, "read timeout") // last error
+}
+
+func TestStatusEndpoint_IncludesFailureReason(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 3)
+	mount.UpdateState(&health.CheckResult{
+		Mount:     mount,
+		Timestamp: time.Now(),
+		Error:     context.DeadlineExceeded,
+	}, 3)
+
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/status", nil))
+
+	var response server.StatusResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response)) // should parse response
+	is.Equal(response.Mounts[0].FailureReason, "timeout") // classified failure reason
+}
+
+// TestProbes_RecoveringMount tests that a recovering mount is alive but not ready
+// until it has passed recoveryThreshold checks in a row.
+func TestProbes_RecoveringMount(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 1)
+	mount.RecoveryThreshold = 3
+	mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: false}, 1)
+	mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: true}, 1)
+
+	is.Equal(mount.GetStatus(), health.StatusRecovering) // mount should be recovering
+
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/live", nil))
+	is.Equal(rec.Code, http.StatusOK) // recovering mount is alive
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/ready", nil))
+	is.Equal(rec.Code, http.StatusServiceUnavailable) // recovering mount is not ready
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/status", nil))
+	is.Equal(rec.Code, http.StatusServiceUnavailable) // status follows readiness
+
+	var response server.StatusResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response)) // should parse response
+	is.Equal(response.Mounts[0].Status, "recovering")     // mount status
+	is.Equal(response.Mounts[0].SuccessCount, 1)          // passes so far
+}
+
+// TestReadiness_FlappingMount tests that a flapping mount is not ready even while
+// its latest check passed.
+func TestReadiness_FlappingMount(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 1)
+	mount.FlapPolicy = health.FlapPolicy{Window: time.Hour, Threshold: 2}
+	now := time.Now()
+	for i, success := range []bool{true, false, true} {
+		mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: now.Add(time.Duration(i) * time.Second), Success: success}, 1)
+	}
+
+	is.Equal(mount.GetStatus(), health.StatusHealthy) // latest check passed
+	is.True(mount.IsFlapping())                       // but the mount is flapping
+
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/ready", nil))
+	is.Equal(rec.Code, http.StatusServiceUnavailable) // flapping mount is not ready
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/status", nil))
+
+	var response server.StatusResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response)) // should parse response
+	is.True(response.Mounts[0].Flapping)                  // flapping reported
+	is.Equal(response.Mounts[0].Transitions, 2)           // transitions in window
+}
+
+func TestStatusEndpoint_IncludesFailureWindow(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 3)
+	mount.FailureWindow = health.FailureWindow{Size: 4, Failures: 3}
+	for _, success := range []bool{true, false, true} {
+		mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: success}, 3)
+	}
+
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/status", nil))
+
+	var response server.StatusResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response)) // should parse response
+
+	window := response.Mounts[0].FailureWindow
+	is.True(window != nil)                                           // window reported
+	is.Equal(window.Size, 4)                                         // window size
+	is.Equal(window.Threshold, 3)                                    // failures threshold
+	is.Equal(window.Failures, 1)                                     // one failure in window
+	is.Equal(window.Results, []string{"passed", "failed", "passed"}) // oldest first
+}
+
+func TestStatusEndpoint_IncludesLatency(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 3)
+	mount.Latency = health.LatencyPolicy{Warn: 3 * time.Second}
+	for _, d := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: true, Duration: d}, 3)
+	}
+
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/status", nil))
+
+	var response server.StatusResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response)) // should parse response
+	is.Equal(response.Mounts[0].Status, "degraded")       // last check was slow
+	is.True(response.Mounts[0].Slow)                      // slowness reported
+
+	latency := response.Mounts[0].Latency
+	is.True(latency != nil)      // latency reported
+	is.Equal(latency.Samples, 3) // all checks sampled
+	is.Equal(latency.P50, "2s")  // median duration
+	is.Equal(latency.P95, "4s")  // slowest check
+}
+
+func TestStatusEndpoint_IncludesCheckInterval(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("", "/mnt/test", ".health-check", 3)
+	mount.Intervals = health.AdaptiveInterval{Degraded: 5 * time.Second}
+	checkedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mount.UpdateState(&health.CheckResult{Mount: mount, Timestamp: checkedAt, Error: errors.New("read failed")}, 3)
+	mount.NextInterval(30 * time.Second)
+
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/status", nil))
+
+	var response server.StatusResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response))              // should parse response
+	is.Equal(response.Mounts[0].CheckInterval, "5s")                   // degraded interval
+	is.Equal(response.Mounts[0].IntervalMode, "degraded")              // interval mode
+	is.Equal(response.Mounts[0].IntervalSince, "2024-05-01T12:00:00Z") // mode entered at the failed check
+}
+
+// hungMountCheck blocks like a read on a hung mount until it is released.
+var hungMountCheck = health.NewBlockingCheckForTesting()
+
+func init() {
+	health.RegisterCheckType("test-blocking", hungMountCheck)
+}
+
+func TestStatusEndpoint_IncludesStuckProbes(t *testing.T) {
+	is := is.New(t)
+	defer hungMountCheck.Release()
+
+	mount := health.NewMountWithCheckType("", t.TempDir(), "", "test-blocking", 3)
+	checker := health.NewChecker(20 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		mount.UpdateState(checker.Check(context.Background(), mount), 3)
+	}
+
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/status", nil))
+
+	var response server.StatusResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response))    // should parse response
+	is.Equal(response.Mounts[0].StuckProbes, 1)              // one blocked probe, not one per check
+	is.Equal(response.Mounts[0].StuckProbeAge, "0s")         // rounded to seconds
+	is.Equal(response.Mounts[0].FailureReason, "probe_hung") // second check did not run
+}
+
+// Tokens accepted by stubAuth.
+const (
+	readToken  = "read-token-0123456789"
+	adminToken = "admin-token-0123456789"
+)
+
+// stubAuth implements server.Authorizer for testing.
+type stubAuth struct{}
+
+func (stubAuth) Authorize(token string) (server.Scope, bool) {
+	switch token {
+	case readToken:
+		return server.ScopeRead, true
+	case adminToken:
+		return server.ScopeAdmin, true
+	}
+	return 0, false
+}
+
+func (stubAuth) Enabled() bool { return true }
+
+// adminRequest returns a request carrying the admin token.
+func adminRequest(method, target string) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	return req
+}
+
+// stubChecker implements server.MountChecker for testing. It fails checks of
+// mounts named "broken" and records the mounts it checked. If err is set, it is
+// returned instead of checking the mount named busy, or any mount if busy is empty.
+type stubChecker struct {
+	mu      sync.Mutex
+	checked []string
+	err     error
+	busy    string
+}
+
+func (c *stubChecker) CheckNow(_ context.Context, mount *health.Mount) (*health.CheckResult, error) {
+	if c.err != nil && (c.busy == "" || c.busy == mount.Name) {
+		return nil, c.err
+	}
+	c.mu.Lock()
+	c.checked = append(c.checked, mount.Name)
+	c.mu.Unlock()
+
+	result := &health.CheckResult{
+		Mount:     mount,
+		Timestamp: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Success:   true,
+		Duration:  15 * time.Millisecond,
+	}
+	if mount.Name == "broken" {
+		result.Success = false
+		result.Error = errors.New("transport endpoint is not connected")
+		result.Reason = health.ReasonNotConnected
+	}
+	mount.UpdateState(result, 1)
+	return result, nil
+}
+
+// TestCheckEndpoint tests that POST /api/v1/mounts/{name}/check checks the named mount.
+func TestCheckEndpoint(t *testing.T) {
+	is := is.New(t)
+
+	movies := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	broken := health.NewMount("broken", "/mnt/broken", ".health-check", 3)
+	srv := server.New([]*health.Mount{movies, broken}, 0, "test", testLogger())
+	srv.SetAuth(stubAuth{})
+	handler := createServerHandler(srv)
+
+	// Without a checker the endpoint is not available
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, adminRequest(http.MethodPost, "/api/v1/mounts/movies/check"))
+	is.Equal(rec.Code, http.StatusNotFound) // should return 404 without checker
+
+	checker := &stubChecker{}
+	srv.SetChecker(checker, time.Second)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, adminRequest(http.MethodPost, "/api/v1/mounts/broken/check"))
+	is.Equal(rec.Code, http.StatusOK) // check ran, whatever its result
+
+	var response server.CheckResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response))           // should parse response
+	is.Equal(response.Name, "broken")                               // checked mount
+	is.Equal(response.Path, "/mnt/broken")                          // mount path
+	is.True(!response.Success)                                      // check failed
+	is.Equal(response.Status, "unhealthy")                          // state after the check
+	is.Equal(response.Timestamp, "2024-05-01T12:00:00Z")            // check time
+	is.Equal(response.Duration, "15ms")                             // check duration
+	is.Equal(response.Error, "transport endpoint is not connected") // check error
+	is.Equal(response.FailureReason, "not_connected")               // classified error
+	is.Equal(checker.checked, []string{"broken"})                   // only the named mount checked
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, adminRequest(http.MethodPost, "/api/v1/mounts/unknown/check"))
+	is.Equal(rec.Code, http.StatusNotFound) // unknown mount
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, adminRequest(http.MethodGet, "/api/v1/mounts/movies/check"))
+	is.Equal(rec.Code, http.StatusMethodNotAllowed) // should reject non-POST
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, adminRequest(http.MethodPost, "/api/v1/mounts/movies"))
+	is.Equal(rec.Code, http.StatusNotFound) // not a check endpoint
+}
+
+// TestCheckAllEndpoint tests that POST /api/v1/mounts/check checks every mount.
+func TestCheckAllEndpoint(t *testing.T) {
+	is := is.New(t)
+
+	movies := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	unnamed := health.NewMount("", "/mnt/tv", ".health-check", 3)
+	srv := server.New([]*health.Mount{movies, unnamed}, 0, "test", testLogger())
+	srv.SetAuth(stubAuth{})
+	srv.SetChecker(&stubChecker{}, time.Second)
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, adminRequest(http.MethodPost, "/api/v1/mounts/check"))
+	is.Equal(rec.Code, http.StatusOK) // should return 200
+
+	var response server.CheckAllResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response)) // should parse response
+	is.Equal(len(response.Results), 2)                    // one result per mount
+	is.Equal(response.Results[0].Path, "/mnt/movies")     // results in mount order
+	is.Equal(response.Results[1].Path, "/mnt/tv")         // unnamed mounts included
+	is.True(response.Results[1].Success)                  // check passed
+	is.Equal(response.Results[1].Status, "healthy")       // state after the check
+}
+
+// TestCheckAllEndpoint_NotRun tests that a mount whose check could not run is
+// reported in the results instead of failing the whole request.
+func TestCheckAllEndpoint_NotRun(t *testing.T) {
+	is := is.New(t)
+
+	movies := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	tv := health.NewMount("tv", "/mnt/tv", ".health-check", 3)
+	srv := server.New([]*health.Mount{movies, tv}, 0, "test", testLogger())
+	srv.SetAuth(stubAuth{})
+	srv.SetChecker(&stubChecker{err: context.DeadlineExceeded, busy: "movies"}, time.Second)
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, adminRequest(http.MethodPost, "/api/v1/mounts/check"))
+	is.Equal(rec.Code, http.StatusOK) // the other mounts were checked
+
+	var response server.CheckAllResponse
+	is.NoErr(json.Unmarshal(rec.Body.Bytes(), &response))                                   // should parse response
+	is.Equal(len(response.Results), 2)                                                      // one result per mount
+	is.Equal(response.Results[0].Name, "movies")                                            // mount that was not checked
+	is.True(!response.Results[0].Success)                                                   // no passing check
+	is.Equal(response.Results[0].Status, "unknown")                                         // state unchanged
+	is.Equal(response.Results[0].Error, "check not run: "+context.DeadlineExceeded.Error()) // why it was not checked
+	is.Equal(response.Results[0].Timestamp, "")                                             // no check time
+	is.True(response.Results[1].Success)                                                    // other mount checked
+	is.Equal(response.Results[1].Error, "")                                                 // without error
+}
+
+// deadlineChecker implements server.MountChecker for testing. It records how
+// long the checks may wait before they are given up.
+type deadlineChecker struct {
+	remaining time.Duration
+	ok        bool
+}
+
+func (c *deadlineChecker) CheckNow(ctx context.Context, mount *health.Mount) (*health.CheckResult, error) {
+	deadline, ok := ctx.Deadline()
+	c.remaining, c.ok = time.Until(deadline), ok
+	return &health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: true}, nil
+}
+
+// TestCheckEndpoint_WaitBounded tests that waiting for a check that is already
+// running is bounded, so the request does not hang on a busy mount.
+func TestCheckEndpoint_WaitBounded(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	srv.SetAuth(stubAuth{})
+	checker := &deadlineChecker{}
+	srv.SetChecker(checker, time.Second)
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, adminRequest(http.MethodPost, "/api/v1/mounts/movies/check"))
+	is.Equal(rec.Code, http.StatusOK)           // should return 200
+	is.True(checker.ok)                         // the wait has a deadline
+	is.True(checker.remaining <= 5*time.Second) // leaves the check time to run
+	is.True(checker.remaining > 4*time.Second)  // but does not give up early
+}
+
+// slowChecker implements server.MountChecker for testing. Its checks pass
+// after delay.
+type slowChecker struct {
+	delay time.Duration
+}
+
+func (c slowChecker) CheckNow(_ context.Context, mount *health.Mount) (*health.CheckResult, error) {
+	time.Sleep(c.delay)
+	return &health.CheckResult{Mount: mount, Timestamp: time.Now(), Success: true, Duration: c.delay}, nil
+}
+
+// TestCheckEndpoint_OutlastsWriteTimeout tests that a check taking longer than
+// the server's write timeout, but within the read timeout, is still returned.
+func TestCheckEndpoint_OutlastsWriteTimeout(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	srv.SetAuth(stubAuth{})
+	srv.SetChecker(slowChecker{delay: 200 * time.Millisecond}, time.Second)
+
+	ts := httptest.NewUnstartedServer(srv.Handler())
+	ts.Config.WriteTimeout = 50 * time.Millisecond
+	ts.Start()
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/api/v1/mounts/movies/check", nil)
+	is.NoErr(err) // should create request
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err := ts.Client().Do(req)
+	is.NoErr(err) // response written after the write timeout
+	defer resp.Body.Close()
+	is.Equal(resp.StatusCode, http.StatusOK) // should return 200
+
+	var response server.CheckResponse
+	is.NoErr(json.NewDecoder(resp.Body).Decode(&response)) // should parse response
+	is.True(response.Success)                              // check passed
+}
+
+// TestCheckEndpoint_NotRun tests that a check that could not run returns 503.
+func TestCheckEndpoint_NotRun(t *testing.T) {
+	is := is.New(t)
+
+	mount := health.NewMount("movies", "/mnt/movies", ".health-check", 3)
+	srv := server.New([]*health.Mount{mount}, 0, "test", testLogger())
+	srv.SetAuth(stubAuth{})
+	srv.SetChecker(&stubChecker{err: context.Canceled}, time.Second)
+	handler := createServerHandler(srv)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, adminRequest(http.MethodPost, "/api/v1/mounts/movies/check"))
+	is.Equal(rec.Code, http.StatusServiceUnavailable) // should return 503
+}
@@ -0,0 +1,18 @@
+// Package server - test helpers
+//
+// This file exports internal functions for use in tests.
+// These functions should not be used in production code.
+package server
+
+import "time"
+
+// SetTokenStatIntervalForTesting changes how often token stores check their file
+// for changes and returns a function that restores the default.
+//
+// WARNING: This function is intended for testing only.
+// Do not use in production code.
+func SetTokenStatIntervalForTesting(interval time.Duration) (restore func()) {
+	previous := tokenStatInterval
+	tokenStatInterval = interval
+	return func() { tokenStatInterval = previous }
+}
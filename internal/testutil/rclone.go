@@ -0,0 +1,112 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// FakeRC is a fake rclone remote control (rc) server for tests.
+// Commands respond with the JSON value set by SetResponse or the error set by
+// SetError; unknown commands respond 404 like a real rc server.
+type FakeRC struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	responses map[string]any
+	errors    map[string]fakeRCError
+	calls     []FakeRCCall
+}
+
+// FakeRCCall records a command received by a FakeRC.
+type FakeRCCall struct {
+	Command string
+	Params  map[string]any
+}
+
+type fakeRCError struct {
+	status  int
+	message string
+}
+
+// NewFakeRC starts a FakeRC that is closed when the test completes.
+//
+// Example:
+//
+//	rc := testutil.NewFakeRC(t)
+//	rc.SetResponse("mount/listmounts", map[string]any{"mountPoints": []any{}})
+//	client := rclone.NewClient(rc.URL, "", "")
+func NewFakeRC(t *testing.T) *FakeRC {
+	t.Helper()
+
+	f := &FakeRC{
+		responses: make(map[string]any),
+		errors:    make(map[string]fakeRCError),
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// SetResponse sets the JSON response for an rc command (e.g. "vfs/stats").
+func (f *FakeRC) SetResponse(command string, response any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses[command] = response
+	delete(f.errors, command)
+}
+
+// SetError makes an rc command fail with the given HTTP status and error message.
+func (f *FakeRC) SetError(command string, status int, message string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errors[command] = fakeRCError{status: status, message: message}
+}
+
+// Calls returns the commands received so far, in order.
+func (f *FakeRC) Calls() []FakeRCCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := make([]FakeRCCall, len(f.calls))
+	copy(calls, f.calls)
+	return calls
+}
+
+// Commands returns the names of the commands received so far, in order.
+func (f *FakeRC) Commands() []string {
+	calls := f.Calls()
+	commands := make([]string, len(calls))
+	for i, c := range calls {
+		commands[i] = c.Command
+	}
+	return commands
+}
+
+func (f *FakeRC) handle(w http.ResponseWriter, r *http.Request) {
+	command := strings.TrimPrefix(r.URL.Path, "/")
+
+	var params map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&params)
+
+	f.mu.Lock()
+	f.calls = append(f.calls, FakeRCCall{Command: command, Params: params})
+	response, ok := f.responses[command]
+	rcErr, failed := f.errors[command]
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case failed:
+		w.WriteHeader(rcErr.status)
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": rcErr.message, "status": rcErr.status, "path": command})
+	case ok:
+		_ = json.NewEncoder(w).Encode(response)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": fmt.Sprintf("couldn't find method %q", command), "status": http.StatusNotFound, "path": command})
+	}
+}
@@ -0,0 +1,41 @@
+package testutil_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/testutil"
+	"github.com/matryer/is"
+)
+
+func TestFakeRC(t *testing.T) {
+	is := is.New(t)
+
+	rc := testutil.NewFakeRC(t)
+	rc.SetResponse("core/stats", map[string]any{"errors": 2})
+	rc.SetError("vfs/refresh", http.StatusInternalServerError, "refresh failed")
+
+	resp, err := http.Post(rc.URL+"/core/stats", "application/json", strings.NewReader(`{}`))
+	is.NoErr(err) // request should succeed
+	var stats map[string]any
+	is.NoErr(json.NewDecoder(resp.Body).Decode(&stats)) // response should be JSON
+	resp.Body.Close()
+	is.Equal(stats["errors"], float64(2)) // configured response
+
+	resp, err = http.Post(rc.URL+"/vfs/refresh", "application/json", strings.NewReader(`{"recursive": true}`))
+	is.NoErr(err) // request should succeed
+	resp.Body.Close()
+	is.Equal(resp.StatusCode, http.StatusInternalServerError) // configured error
+
+	resp, err = http.Post(rc.URL+"/unknown", "application/json", nil)
+	is.NoErr(err) // request should succeed
+	resp.Body.Close()
+	is.Equal(resp.StatusCode, http.StatusNotFound) // unknown command
+
+	calls := rc.Calls()
+	is.Equal(len(calls), 3)                      // all calls recorded
+	is.Equal(calls[1].Command, "vfs/refresh")    // command name
+	is.Equal(calls[1].Params["recursive"], true) // params recorded
+}
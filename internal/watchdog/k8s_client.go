@@ -250,6 +250,15 @@ func (c *K8sClient) CreateEvent(ctx context.Context, event *RestartEvent) error
 	now := time.Now().UTC().Format(time.RFC3339)
 	eventName := fmt.Sprintf("mount-monitor.%d", time.Now().UnixNano())
 
+	reason := event.EventReason
+	if reason == "" {
+		reason = EventReasonRestart
+	}
+	eventType := event.EventType
+	if eventType == "" {
+		eventType = "Warning"
+	}
+
 	eventBody := map[string]interface{}{
 		"apiVersion": "v1",
 		"kind":       "Event",
@@ -263,9 +272,9 @@ func (c *K8sClient) CreateEvent(ctx context.Context, event *RestartEvent) error
 			"name":       event.PodName,
 			"namespace":  c.namespace,
 		},
-		"reason":         "WatchdogRestart",
+		"reason":         reason,
 		"message":        event.Reason,
-		"type":           "Warning",
+		"type":           eventType,
 		"firstTimestamp": now,
 		"lastTimestamp":  now,
 		"count":          1,
@@ -301,7 +310,7 @@ func (c *K8sClient) CreateEvent(ctx context.Context, event *RestartEvent) error
 	c.logger.Info("kubernetes event created",
 		"event", eventName,
 		"pod", event.PodName,
-		"reason", "WatchdogRestart")
+		"reason", reason)
 
 	return nil
 }
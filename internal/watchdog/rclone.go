@@ -0,0 +1,111 @@
+package watchdog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/rclone"
+)
+
+// rclone rc remediation step names.
+const (
+	RemediationVFSRefresh = "vfs-refresh"
+	RemediationRemount    = "remount"
+)
+
+// RcloneTarget identifies the rclone instance serving a mount through its rc API.
+type RcloneTarget struct {
+	URL        string // rc server URL, e.g. "http://localhost:5572"
+	Username   string // rc basic auth username (optional)
+	Password   string // rc basic auth password (optional)
+	MountPoint string // Mount point as rclone sees it (empty = mount path)
+	Remote     string // Remote to mount if rclone no longer lists the mount (optional)
+}
+
+// NewRcloneRemediation returns the rclone rc remediation step with the given name,
+// RemediationVFSRefresh or RemediationRemount. targets maps mount paths to the rclone
+// instance serving them; the step is skipped for mounts without a target.
+func NewRcloneRemediation(name string, targets map[string]RcloneTarget) (Remediation, error) {
+	switch name {
+	case RemediationVFSRefresh:
+		return &rcloneVFSRefresh{targets: targets}, nil
+	case RemediationRemount:
+		return &rcloneRemount{targets: targets}, nil
+	default:
+		return nil, fmt.Errorf("unknown rclone remediation %q", name)
+	}
+}
+
+// rcloneVFSRefresh re-reads the mount's root directory from the remote (vfs/refresh),
+// dropping stale directory cache entries that make files disappear or hang.
+type rcloneVFSRefresh struct {
+	targets map[string]RcloneTarget
+}
+
+func (r *rcloneVFSRefresh) Name() string { return RemediationVFSRefresh }
+
+func (r *rcloneVFSRefresh) Remediate(ctx context.Context, mountPath string, _ error) error {
+	target, client, ok := rcloneClient(r.targets, mountPath)
+	if !ok {
+		return fmt.Errorf("%w: no rclone rc endpoint configured for %s", ErrRemediationSkipped, mountPath)
+	}
+
+	mounts, err := client.ListMounts(ctx)
+	if err != nil {
+		return err
+	}
+	mount := rclone.FindMount(mounts, target.MountPoint)
+	if mount == nil {
+		return fmt.Errorf("rclone does not list mount %s", target.MountPoint)
+	}
+	return client.Call(ctx, "vfs/refresh", map[string]string{"fs": mount.Fs}, nil)
+}
+
+// rcloneRemount unmounts the mount (mount/unmount) and mounts its remote again
+// (mount/mount). If rclone no longer lists the mount, the configured remote is mounted.
+type rcloneRemount struct {
+	targets map[string]RcloneTarget
+}
+
+func (r *rcloneRemount) Name() string { return RemediationRemount }
+
+func (r *rcloneRemount) Remediate(ctx context.Context, mountPath string, _ error) error {
+	target, client, ok := rcloneClient(r.targets, mountPath)
+	if !ok {
+		return fmt.Errorf("%w: no rclone rc endpoint configured for %s", ErrRemediationSkipped, mountPath)
+	}
+
+	mounts, err := client.ListMounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	remote := target.Remote
+	if mount := rclone.FindMount(mounts, target.MountPoint); mount != nil {
+		remote = mount.Fs
+		params := map[string]string{"mountPoint": target.MountPoint}
+		if err := client.Call(ctx, "mount/unmount", params, nil); err != nil {
+			return err
+		}
+	}
+	if remote == "" {
+		return fmt.Errorf("rclone does not list mount %s and no remote is configured to mount", target.MountPoint)
+	}
+
+	// mount/mount uses rclone's own mount and VFS options (its command-line flags)
+	params := map[string]string{"fs": remote, "mountPoint": target.MountPoint}
+	return client.Call(ctx, "mount/mount", params, nil)
+}
+
+// rcloneClient returns the target for mountPath, with MountPoint defaulted to the
+// mount path, and a client for its rc endpoint.
+func rcloneClient(targets map[string]RcloneTarget, mountPath string) (RcloneTarget, *rclone.Client, bool) {
+	target, ok := targets[mountPath]
+	if !ok || target.URL == "" {
+		return RcloneTarget{}, nil, false
+	}
+	if target.MountPoint == "" {
+		target.MountPoint = mountPath
+	}
+	return target, rclone.NewClient(target.URL, target.Username, target.Password), true
+}
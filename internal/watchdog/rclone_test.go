@@ -0,0 +1,81 @@
+package watchdog_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/testutil"
+	"github.com/cscheib/debrid-mount-monitor/internal/watchdog"
+	"github.com/matryer/is"
+)
+
+// newListingRC returns a fake rc server that lists the given mounts and accepts
+// the remediation commands.
+func newListingRC(t *testing.T, mounts ...map[string]any) *testutil.FakeRC {
+	t.Helper()
+	rc := testutil.NewFakeRC(t)
+	rc.SetResponse("mount/listmounts", map[string]any{"mountPoints": mounts})
+	for _, command := range []string{"vfs/refresh", "mount/unmount", "mount/mount"} {
+		rc.SetResponse(command, map[string]any{})
+	}
+	return rc
+}
+
+func TestRcloneRemediation_VFSRefresh(t *testing.T) {
+	is := is.New(t)
+
+	rc := newListingRC(t, map[string]any{"Fs": "debrid:", "MountPoint": "/mnt/debrid"})
+	step, err := watchdog.NewRcloneRemediation(watchdog.RemediationVFSRefresh, map[string]watchdog.RcloneTarget{
+		"/mnt/debrid": {URL: rc.URL},
+	})
+	is.NoErr(err) // known step
+
+	is.NoErr(step.Remediate(context.Background(), "/mnt/debrid", nil))   // refresh should succeed
+	is.Equal(rc.Commands(), []string{"mount/listmounts", "vfs/refresh"}) // rc commands
+	is.Equal(rc.Calls()[1].Params["fs"], "debrid:")                      // refreshes the mount's remote
+}
+
+func TestRcloneRemediation_Remount(t *testing.T) {
+	is := is.New(t)
+
+	rc := newListingRC(t, map[string]any{"Fs": "debrid:", "MountPoint": "/data/debrid"})
+	step, err := watchdog.NewRcloneRemediation(watchdog.RemediationRemount, map[string]watchdog.RcloneTarget{
+		"/mnt/debrid": {URL: rc.URL, MountPoint: "/data/debrid"},
+	})
+	is.NoErr(err) // known step
+
+	is.NoErr(step.Remediate(context.Background(), "/mnt/debrid", nil))                    // remount should succeed
+	is.Equal(rc.Commands(), []string{"mount/listmounts", "mount/unmount", "mount/mount"}) // rc commands
+	is.Equal(rc.Calls()[1].Params["mountPoint"], "/data/debrid")                          // unmounts rclone's mount point
+	is.Equal(rc.Calls()[2].Params["fs"], "debrid:")                                       // mounts the same remote
+}
+
+func TestRcloneRemediation_RemountMissingMount(t *testing.T) {
+	is := is.New(t)
+
+	rc := newListingRC(t)
+	targets := map[string]watchdog.RcloneTarget{"/mnt/debrid": {URL: rc.URL, Remote: "debrid:"}}
+	step, err := watchdog.NewRcloneRemediation(watchdog.RemediationRemount, targets)
+	is.NoErr(err) // known step
+
+	is.NoErr(step.Remediate(context.Background(), "/mnt/debrid", nil))   // remount should succeed
+	is.Equal(rc.Commands(), []string{"mount/listmounts", "mount/mount"}) // nothing to unmount
+	is.Equal(rc.Calls()[1].Params["fs"], "debrid:")                      // mounts the configured remote
+
+	targets["/mnt/debrid"] = watchdog.RcloneTarget{URL: rc.URL}
+	is.True(step.Remediate(context.Background(), "/mnt/debrid", nil) != nil) // no remote to mount
+}
+
+func TestRcloneRemediation_SkipsMountsWithoutTarget(t *testing.T) {
+	is := is.New(t)
+
+	step, err := watchdog.NewRcloneRemediation(watchdog.RemediationVFSRefresh, nil)
+	is.NoErr(err) // known step
+
+	err = step.Remediate(context.Background(), "/mnt/debrid", nil)
+	is.True(errors.Is(err, watchdog.ErrRemediationSkipped)) // no rc endpoint configured
+
+	_, err = watchdog.NewRcloneRemediation("vfs-forget", nil)
+	is.True(err != nil) // unknown step
+}
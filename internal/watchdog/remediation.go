@@ -0,0 +1,167 @@
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRemediationSkipped is returned by a Remediation that does not apply to a mount,
+// for example because no rclone rc endpoint is configured for it. Skipped steps are
+// not counted as attempts and the next step is tried immediately.
+var ErrRemediationSkipped = errors.New("remediation not applicable")
+
+// remediationTimeout bounds a single remediation step. Remounting a debrid remote
+// can take a while, but a step that hangs must not block escalation to a pod restart.
+const remediationTimeout = 30 * time.Second
+
+// Remediation is one step of the watchdog's remediation ladder. When a mount becomes
+// unhealthy the armed watchdog tries each step in order, waiting for the next check
+// results after each, and only deletes the pod if the mount stays unhealthy.
+type Remediation interface {
+	// Name identifies the step in logs and Kubernetes events, e.g. "vfs-refresh".
+	Name() string
+
+	// Remediate attempts to repair the mount at mountPath. cause is the mount's
+	// last check error (nil if unknown). It returns ErrRemediationSkipped if the
+	// step does not apply to the mount.
+	Remediate(ctx context.Context, mountPath string, cause error) error
+}
+
+// nextRemediationLocked starts the next remediation step for the pending mount, or
+// schedules the pod restart once all steps are exhausted. Must be called with w.mu held.
+func (w *Watchdog) nextRemediationLocked() {
+	w.awaitingChecks = 0
+	w.remediationIndex++
+
+	if w.remediationIndex >= len(w.config.Remediations) {
+		w.logger.Warn("watchdog remediation exhausted, escalating to restart",
+			"mount_path", w.state.PendingMount,
+			"last_step", w.remediatedStep)
+		w.state.RemediationStep = ""
+		w.schedulePendingRestartLocked()
+		return
+	}
+
+	step := w.config.Remediations[w.remediationIndex]
+	w.state.RemediationStep = step.Name()
+	go w.runRemediation(step, w.state.PendingMount, w.lastErrors[w.state.PendingMount], w.remediationGen)
+}
+
+// runRemediation runs a remediation step and, once it completes, either waits for
+// the next check results or moves on to the next step. gen identifies the
+// remediation run the step belongs to; if the mount recovered in the meantime the
+// result is ignored.
+func (w *Watchdog) runRemediation(step Remediation, mountPath string, cause error, gen int) {
+	// Use stored context if available, otherwise background
+	ctx := w.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if ctx.Err() != nil {
+		w.logger.Info("watchdog remediation aborted due to shutdown")
+		return
+	}
+
+	w.logger.Warn("watchdog remediation started",
+		"mount_path", mountPath,
+		"step", step.Name())
+
+	stepCtx, cancel := context.WithTimeout(ctx, remediationTimeout)
+	start := time.Now()
+	err := step.Remediate(stepCtx, mountPath, cause)
+	cancel()
+	duration := time.Since(start)
+
+	w.mu.Lock()
+	if w.state.State != WatchdogRemediating || w.remediationGen != gen {
+		// Mount recovered (or watchdog shut down) while the step was running
+		w.mu.Unlock()
+		return
+	}
+
+	if errors.Is(err, ErrRemediationSkipped) {
+		w.logger.Info("watchdog remediation step skipped",
+			"mount_path", mountPath,
+			"step", step.Name(),
+			"reason", err)
+		w.nextRemediationLocked()
+		w.mu.Unlock()
+		return
+	}
+
+	w.state.RemediationCount++
+	w.remediatedStep = step.Name()
+	event := &RestartEvent{
+		Timestamp:    time.Now(),
+		PodName:      w.podName,
+		Namespace:    w.namespace,
+		MountPath:    mountPath,
+		FailureCount: w.failureCount,
+	}
+	if w.state.UnhealthySince != nil {
+		event.UnhealthyDuration = time.Since(*w.state.UnhealthySince)
+	}
+
+	if err != nil {
+		// A failed step cannot have fixed the mount; don't wait for checks
+		w.logger.Error("watchdog remediation step failed",
+			"mount_path", mountPath,
+			"step", step.Name(),
+			"duration", duration,
+			"error", err)
+		event.EventReason = EventReasonRemediationFailed
+		event.Reason = fmt.Sprintf("Remediation step %s for mount %s failed: %v", step.Name(), mountPath, err)
+		w.nextRemediationLocked()
+	} else {
+		w.awaitingChecks = max(w.config.RemediationChecks, 1)
+		w.logger.Warn("watchdog remediation step completed, waiting for health checks",
+			"mount_path", mountPath,
+			"step", step.Name(),
+			"duration", duration,
+			"checks", w.awaitingChecks)
+		event.EventReason = EventReasonRemediation
+		event.Reason = fmt.Sprintf("Mount %s unhealthy after %d consecutive failures, ran remediation step %s", mountPath, w.failureCount, step.Name())
+	}
+	w.mu.Unlock()
+
+	w.emitEvent(ctx, event)
+}
+
+// endRemediationLocked ends remediation after the pending mount recovered and
+// re-arms the watchdog. Must be called with w.mu held.
+func (w *Watchdog) endRemediationLocked(mountPath string) {
+	step := w.remediatedStep
+	w.logger.Info("watchdog remediation succeeded",
+		"mount_path", mountPath,
+		"step", step)
+
+	w.remediationGen++
+	w.awaitingChecks = 0
+	w.remediatedStep = ""
+	w.state.State = WatchdogArmed
+	w.state.RemediationStep = ""
+	w.state.UnhealthySince = nil
+	w.state.PendingMount = ""
+
+	if step == "" {
+		// Recovered on its own before any step ran
+		return
+	}
+
+	event := &RestartEvent{
+		Timestamp:   time.Now(),
+		PodName:     w.podName,
+		Namespace:   w.namespace,
+		MountPath:   mountPath,
+		Reason:      fmt.Sprintf("Mount %s recovered after remediation step %s", mountPath, step),
+		EventReason: EventReasonRemediationSucceeded,
+		EventType:   "Normal",
+	}
+	ctx := w.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	go w.emitEvent(ctx, event)
+}
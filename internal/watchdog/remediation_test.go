@@ -0,0 +1,167 @@
+package watchdog_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/testutil"
+	"github.com/cscheib/debrid-mount-monitor/internal/watchdog"
+	"github.com/matryer/is"
+)
+
+// fakeRemediation is a Remediation that records its calls and returns err.
+type fakeRemediation struct {
+	name string
+	err  error
+
+	mu     sync.Mutex
+	calls  []string
+	causes []error
+}
+
+func (f *fakeRemediation) Name() string { return f.name }
+
+func (f *fakeRemediation) Remediate(_ context.Context, mountPath string, cause error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, mountPath)
+	f.causes = append(f.causes, cause)
+	return f.err
+}
+
+func (f *fakeRemediation) cause(i int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.causes[i]
+}
+
+func (f *fakeRemediation) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+// newRemediatingWatchdog returns an armed watchdog with an immediate restart and the given steps.
+func newRemediatingWatchdog(client *MockK8sClient, steps ...watchdog.Remediation) *watchdog.Watchdog {
+	cfg := watchdog.Config{
+		Enabled:             true,
+		RestartDelay:        0,
+		MaxRetries:          1,
+		RetryBackoffInitial: time.Millisecond,
+		RetryBackoffMax:     time.Millisecond,
+		Remediations:        steps,
+		RemediationChecks:   1,
+	}
+	wd := watchdog.NewWatchdog(cfg, "test-pod", "test-ns", testLogger())
+	wd.SetK8sClient(client)
+	wd.SetExitFunc(func(int) {})
+	wd.SetArmed()
+	return wd
+}
+
+// eventReasons returns the reasons of the events created so far.
+func eventReasons(client *MockK8sClient) []string {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	reasons := make([]string, len(client.CreateEventCalls))
+	for i, e := range client.CreateEventCalls {
+		reasons[i] = e.EventReason
+	}
+	return reasons
+}
+
+// awaitingChecks reports whether the watchdog finished the current step and is waiting for checks.
+func awaitingChecks(wd *watchdog.Watchdog, client *MockK8sClient, events int) func() bool {
+	return func() bool {
+		return wd.State().State == watchdog.WatchdogRemediating && len(eventReasons(client)) == events
+	}
+}
+
+// TestWatchdog_RemediationRecovers verifies a mount that recovers after a remediation
+// step re-arms the watchdog without deleting the pod.
+func TestWatchdog_RemediationRecovers(t *testing.T) {
+	is := is.New(t)
+
+	client := &MockK8sClient{}
+	refresh := &fakeRemediation{name: "vfs-refresh"}
+	remount := &fakeRemediation{name: "remount"}
+	wd := newRemediatingWatchdog(client, refresh, remount)
+
+	checkErr := errors.New("transport endpoint is not connected")
+	wd.OnMountChecked("/mnt/test", checkErr)
+	wd.OnMountUnhealthy("/mnt/test", 3)
+	testutil.PollUntil(t, time.Second, awaitingChecks(wd, client, 1))
+
+	state := wd.State()
+	is.Equal(state.RemediationStep, "vfs-refresh") // first step in progress
+	is.Equal(state.RemediationCount, 1)            // one step attempted
+	is.Equal(refresh.cause(0), checkErr)           // step receives the last check error
+
+	wd.OnMountChecked("/mnt/test", nil)
+	wd.OnMountHealthy("/mnt/test")
+	testutil.PollUntil(t, time.Second, func() bool { return len(eventReasons(client)) == 2 })
+
+	is.Equal(wd.State().State, watchdog.WatchdogArmed) // watchdog re-armed
+	is.Equal(remount.callCount(), 0)                   // later steps not run
+	is.Equal(len(client.DeletePodCalls), 0)            // pod not deleted
+	is.Equal(eventReasons(client), []string{watchdog.EventReasonRemediation, watchdog.EventReasonRemediationSucceeded})
+}
+
+// TestWatchdog_RemediationEscalatesToRestart verifies each step is tried after the
+// previous one failed to recover the mount, then the pod is deleted.
+func TestWatchdog_RemediationEscalatesToRestart(t *testing.T) {
+	is := is.New(t)
+
+	client := &MockK8sClient{}
+	refresh := &fakeRemediation{name: "vfs-refresh"}
+	remount := &fakeRemediation{name: "remount"}
+	wd := newRemediatingWatchdog(client, refresh, remount)
+
+	checkErr := errors.New("read timeout")
+	wd.OnMountUnhealthy("/mnt/test", 3)
+	testutil.PollUntil(t, time.Second, awaitingChecks(wd, client, 1))
+
+	// Results of other mounts don't advance the ladder
+	wd.OnMountChecked("/mnt/other", checkErr)
+	is.Equal(remount.callCount(), 0) // other mount ignored
+
+	wd.OnMountChecked("/mnt/test", checkErr)
+	testutil.PollUntil(t, time.Second, awaitingChecks(wd, client, 2))
+	is.Equal(wd.State().RemediationStep, "remount") // second step in progress
+
+	wd.OnMountChecked("/mnt/test", checkErr)
+	testutil.PollUntil(t, time.Second, func() bool {
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		return len(client.DeletePodCalls) == 1
+	})
+
+	is.Equal(refresh.callCount(), 1)                       // first step ran once
+	is.Equal(remount.callCount(), 1)                       // second step ran once
+	is.Equal(wd.State().State, watchdog.WatchdogTriggered) // escalated to restart
+	is.Equal(eventReasons(client), []string{watchdog.EventReasonRemediation, watchdog.EventReasonRemediation, watchdog.EventReasonRestart})
+}
+
+// TestWatchdog_RemediationSkippedAndFailedSteps verifies skipped steps are passed over
+// silently and failed steps advance without waiting for checks.
+func TestWatchdog_RemediationSkippedAndFailedSteps(t *testing.T) {
+	is := is.New(t)
+
+	client := &MockK8sClient{}
+	skipped := &fakeRemediation{name: "skipped", err: fmt.Errorf("%w: no endpoint", watchdog.ErrRemediationSkipped)}
+	failing := &fakeRemediation{name: "failing", err: errors.New("rc unreachable")}
+	last := &fakeRemediation{name: "last"}
+	wd := newRemediatingWatchdog(client, skipped, failing, last)
+
+	wd.OnMountUnhealthy("/mnt/test", 3)
+	testutil.PollUntil(t, time.Second, awaitingChecks(wd, client, 2))
+
+	state := wd.State()
+	is.Equal(state.RemediationStep, "last") // skipped and failed steps passed over
+	is.Equal(state.RemediationCount, 2)     // skipped step not counted
+	is.Equal(eventReasons(client), []string{watchdog.EventReasonRemediationFailed, watchdog.EventReasonRemediation})
+}
@@ -0,0 +1,75 @@
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+)
+
+// RemediationLazyUnmount is the name of the lazy unmount remediation step.
+const RemediationLazyUnmount = "lazy-unmount"
+
+// Unmounter detaches the filesystem mounted at a path.
+type Unmounter interface {
+	Unmount(path string) error
+}
+
+// staleMountMessages are error message fragments reported for a dead FUSE mount,
+// for errors that don't wrap the underlying errno (e.g. from exec check output).
+var staleMountMessages = []string{
+	"transport endpoint is not connected",
+	"stale file handle",
+	"stale nfs file handle",
+}
+
+// NewLazyUnmountRemediation returns a remediation step that lazily unmounts
+// (umount -l) a mount whose checks fail with a stale-mount error such as ENOTCONN,
+// so the container that owns the mount can mount it again. It is meant for pods
+// sharing the mount through mountPropagation: Bidirectional, and needs CAP_SYS_ADMIN.
+// If unmounter is nil, the unmount syscall is used (Linux only).
+func NewLazyUnmountRemediation(unmounter Unmounter) Remediation {
+	if unmounter == nil {
+		unmounter = lazyUnmounter{}
+	}
+	return &lazyUnmount{unmounter: unmounter}
+}
+
+// lazyUnmount detaches a stale mount.
+type lazyUnmount struct {
+	unmounter Unmounter
+}
+
+func (l *lazyUnmount) Name() string { return RemediationLazyUnmount }
+
+// Remediate unmounts the mount only if cause indicates a stale mount; an unmount
+// of a healthy but slow mount would break it for everyone sharing it.
+func (l *lazyUnmount) Remediate(_ context.Context, mountPath string, cause error) error {
+	if !IsStaleMountError(cause) {
+		return fmt.Errorf("%w: last error does not indicate a stale mount", ErrRemediationSkipped)
+	}
+	if err := l.unmounter.Unmount(mountPath); err != nil {
+		return fmt.Errorf("lazy unmount of %s: %w", mountPath, err)
+	}
+	return nil
+}
+
+// IsStaleMountError reports whether err indicates a dead mount that can only be
+// cleared by unmounting it, such as ENOTCONN ("transport endpoint is not connected")
+// after the FUSE daemon exited, or ESTALE.
+func IsStaleMountError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, syscall.ENOTCONN) || errors.Is(err, syscall.ESTALE) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, m := range staleMountMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
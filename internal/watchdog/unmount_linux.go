@@ -0,0 +1,14 @@
+//go:build linux
+
+package watchdog
+
+import "syscall"
+
+// lazyUnmounter detaches mounts with umount2(MNT_DETACH), the equivalent of umount -l.
+// The mount disappears from the namespace immediately; processes with open files
+// keep their references until they close them.
+type lazyUnmounter struct{}
+
+func (lazyUnmounter) Unmount(path string) error {
+	return syscall.Unmount(path, syscall.MNT_DETACH)
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package watchdog
+
+import "errors"
+
+// lazyUnmounter is unsupported on platforms other than Linux.
+type lazyUnmounter struct{}
+
+func (lazyUnmounter) Unmount(string) error {
+	return errors.New("lazy unmount is only supported on Linux")
+}
@@ -0,0 +1,83 @@
+package watchdog_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"syscall"
+	"testing"
+
+	"github.com/cscheib/debrid-mount-monitor/internal/watchdog"
+	"github.com/matryer/is"
+)
+
+// fakeUnmounter records unmounted paths instead of unmounting them.
+type fakeUnmounter struct {
+	err   error
+	paths []string
+}
+
+func (f *fakeUnmounter) Unmount(path string) error {
+	f.paths = append(f.paths, path)
+	return f.err
+}
+
+func TestLazyUnmountRemediation(t *testing.T) {
+	staleErr := &fs.PathError{Op: "open", Path: "/mnt/debrid/.health-check", Err: syscall.ENOTCONN}
+
+	tests := []struct {
+		name        string
+		cause       error
+		unmountErr  error
+		wantUnmount bool
+		wantSkipped bool
+		wantErr     bool
+	}{
+		{name: "stale mount", cause: staleErr, wantUnmount: true},
+		{name: "unmount fails", cause: staleErr, unmountErr: syscall.EPERM, wantUnmount: true, wantErr: true},
+		{name: "not stale", cause: context.DeadlineExceeded, wantSkipped: true},
+		{name: "unknown cause", cause: nil, wantSkipped: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			unmounter := &fakeUnmounter{err: tt.unmountErr}
+			step := watchdog.NewLazyUnmountRemediation(unmounter)
+			is.Equal(step.Name(), watchdog.RemediationLazyUnmount) // step name
+
+			err := step.Remediate(context.Background(), "/mnt/debrid", tt.cause)
+
+			is.Equal(len(unmounter.paths) == 1, tt.wantUnmount)                      // unmount attempted
+			is.Equal(errors.Is(err, watchdog.ErrRemediationSkipped), tt.wantSkipped) // skipped when not stale
+			is.Equal(err != nil && !tt.wantSkipped, tt.wantErr)                      // unmount error reported
+			if tt.wantUnmount {
+				is.Equal(unmounter.paths[0], "/mnt/debrid") // unmounts the mount path
+			}
+		})
+	}
+}
+
+func TestIsStaleMountError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"ENOTCONN", &fs.PathError{Op: "stat", Path: "/mnt/debrid", Err: syscall.ENOTCONN}, true},
+		{"ESTALE", fmt.Errorf("listing check: %w", syscall.ESTALE), true},
+		{"message", errors.New("exec check failed: ls: cannot access '/mnt/debrid': Transport endpoint is not connected"), true},
+		{"not found", fs.ErrNotExist, false},
+		{"timeout", context.DeadlineExceeded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(watchdog.IsStaleMountError(tt.err), tt.want) // stale mount classification
+		})
+	}
+}
@@ -1,6 +1,8 @@
 // Package watchdog provides pod-level restart capabilities when mount health checks fail.
 // It monitors mount state transitions and triggers Kubernetes pod deletion to ensure
-// all containers in a pod restart together with fresh mount connections.
+// all containers in a pod restart together with fresh mount connections. Before
+// deleting the pod it can try a ladder of less disruptive remediation steps, such as
+// refreshing or remounting the mount through rclone's remote control API.
 package watchdog
 
 import (
@@ -24,6 +26,8 @@ const (
 	WatchdogPendingRestart
 	// WatchdogTriggered indicates pod deletion is in progress.
 	WatchdogTriggered
+	// WatchdogRemediating indicates remediation steps are being tried before a restart.
+	WatchdogRemediating
 )
 
 // String returns a human-readable representation of the watchdog status.
@@ -37,6 +41,8 @@ func (s WatchdogStatus) String() string {
 		return "pending_restart"
 	case WatchdogTriggered:
 		return "triggered"
+	case WatchdogRemediating:
+		return "remediating"
 	default:
 		return "unknown"
 	}
@@ -54,9 +60,16 @@ type WatchdogState struct {
 	RetryCount int
 	// LastError is the last error encountered (for logging).
 	LastError error
+	// RestartCount is the number of pod restarts triggered since startup.
+	RestartCount int
+	// RemediationStep is the name of the remediation step in progress (empty if none).
+	RemediationStep string
+	// RemediationCount is the number of remediation steps attempted since startup.
+	RemediationCount int
 }
 
-// RestartEvent represents a watchdog-triggered restart for logging and Kubernetes events.
+// RestartEvent represents a watchdog action, a restart or a remediation step,
+// for logging and Kubernetes events.
 type RestartEvent struct {
 	// Timestamp is when the restart was triggered.
 	Timestamp time.Time
@@ -72,8 +85,20 @@ type RestartEvent struct {
 	FailureCount int
 	// UnhealthyDuration is how long the mount was unhealthy.
 	UnhealthyDuration time.Duration
+	// EventReason is the Kubernetes event reason (default "WatchdogRestart").
+	EventReason string
+	// EventType is the Kubernetes event type, "Normal" or "Warning" (default "Warning").
+	EventType string
 }
 
+// Kubernetes event reasons used by the watchdog.
+const (
+	EventReasonRestart              = "WatchdogRestart"
+	EventReasonRemediation          = "WatchdogRemediation"
+	EventReasonRemediationFailed    = "WatchdogRemediationFailed"
+	EventReasonRemediationSucceeded = "WatchdogRemediationSucceeded"
+)
+
 // Config holds the watchdog configuration.
 type Config struct {
 	Enabled             bool
@@ -81,6 +106,13 @@ type Config struct {
 	MaxRetries          int
 	RetryBackoffInitial time.Duration
 	RetryBackoffMax     time.Duration
+
+	// Remediations are tried in order when a mount becomes unhealthy, before the
+	// pod is deleted. Empty means the pod is deleted without remediation.
+	Remediations []Remediation
+	// RemediationChecks is the number of failed checks to wait for after a
+	// remediation step before trying the next one (values < 1 are treated as 1).
+	RemediationChecks int
 }
 
 // K8sClientInterface defines the Kubernetes client operations needed by the watchdog.
@@ -124,17 +156,27 @@ type Watchdog struct {
 
 	// Failure count tracker (passed from mount state)
 	failureCount int
+
+	// Remediation ladder progress for the pending mount
+	remediationIndex int    // Index of the current step in config.Remediations
+	remediationGen   int    // Incremented when remediation ends, so stale step results are ignored
+	awaitingChecks   int    // Failed checks still expected before the next step (0 = step running)
+	remediatedStep   string // Last step that ran (not skipped), for the recovery event
+
+	// Last check error per mount path, passed to remediation steps
+	lastErrors map[string]error
 }
 
 // NewWatchdog creates a new Watchdog instance.
 // If not running in Kubernetes or RBAC permissions are missing, the watchdog will be disabled.
 func NewWatchdog(cfg Config, podName, namespace string, logger *slog.Logger) *Watchdog {
 	w := &Watchdog{
-		config:    cfg,
-		podName:   podName,
-		namespace: namespace,
-		logger:    logger,
-		exitFunc:  os.Exit,
+		config:     cfg,
+		podName:    podName,
+		namespace:  namespace,
+		logger:     logger,
+		exitFunc:   os.Exit,
+		lastErrors: make(map[string]error),
 		state: WatchdogState{
 			State: WatchdogDisabled,
 		},
@@ -208,54 +250,101 @@ func (w *Watchdog) Start(ctx context.Context) error {
 }
 
 // OnMountUnhealthy is called when a mount transitions to unhealthy state.
-// It triggers the restart sequence if the watchdog is armed.
+// It starts the remediation ladder, or the restart sequence if no remediation
+// steps are configured, if the watchdog is armed.
 // The failureCount parameter tracks how many consecutive failures occurred.
 func (w *Watchdog) OnMountUnhealthy(mountPath string, failureCount int) {
 	w.mu.Lock()
+	defer w.mu.Unlock()
 
 	if w.state.State != WatchdogArmed {
-		w.mu.Unlock()
 		return
 	}
 
 	now := time.Now()
 	w.state.UnhealthySince = &now
 	w.state.PendingMount = mountPath
-	w.state.State = WatchdogPendingRestart
 	w.failureCount = failureCount
 
-	// Create cancel channel before releasing lock to prevent race
+	if len(w.config.Remediations) > 0 {
+		w.state.State = WatchdogRemediating
+		w.remediationIndex = -1
+		w.remediatedStep = ""
+		w.nextRemediationLocked()
+		return
+	}
+
+	w.schedulePendingRestartLocked()
+}
+
+// schedulePendingRestartLocked moves the watchdog to PendingRestart and schedules
+// the pod restart after RestartDelay. Must be called with w.mu held.
+func (w *Watchdog) schedulePendingRestartLocked() {
+	w.state.State = WatchdogPendingRestart
+
+	// Create cancel channel while holding the lock to prevent race
 	w.cancelRestart = make(chan struct{})
 	cancelCh := w.cancelRestart // Capture for goroutine
 
 	w.logger.Warn("watchdog restart pending",
-		"mount_path", mountPath,
-		"failure_count", failureCount,
+		"mount_path", w.state.PendingMount,
+		"failure_count", w.failureCount,
 		"delay", w.config.RestartDelay)
 
-	w.mu.Unlock()
-
 	if w.config.RestartDelay == 0 {
 		// Immediate restart - pass cancel channel
 		go w.triggerRestart(cancelCh)
+		return
+	}
+
+	// Delayed restart - use NewTimer to avoid race condition where
+	// AfterFunc callback fires before w.restartTimer is assigned
+	timer := time.NewTimer(w.config.RestartDelay)
+	w.restartTimer = timer
+
+	go func() {
+		select {
+		case <-timer.C:
+			w.triggerRestart(cancelCh)
+		case <-cancelCh:
+			// Restart was cancelled, stop the timer
+			timer.Stop()
+		}
+	}()
+}
+
+// OnMountChecked is called with the result of every health check of a mount
+// (checkErr is nil if the check passed). While remediating, failed checks of the
+// pending mount advance the remediation ladder.
+func (w *Watchdog) OnMountChecked(mountPath string, checkErr error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if checkErr != nil {
+		w.lastErrors[mountPath] = checkErr
 	} else {
-		// Delayed restart - use NewTimer to avoid race condition where
-		// AfterFunc callback fires before w.restartTimer is assigned
-		w.mu.Lock()
-		timer := time.NewTimer(w.config.RestartDelay)
-		w.restartTimer = timer
-		w.mu.Unlock()
+		delete(w.lastErrors, mountPath)
+	}
+
+	if w.state.State != WatchdogRemediating || w.state.PendingMount != mountPath {
+		return
+	}
+	// Ignore results while a step is running, and passing checks: recovery is
+	// handled by OnMountHealthy once the mount is healthy again
+	if w.awaitingChecks == 0 || checkErr == nil {
+		return
+	}
 
-		go func() {
-			select {
-			case <-timer.C:
-				w.triggerRestart(cancelCh)
-			case <-cancelCh:
-				// Restart was cancelled, stop the timer
-				timer.Stop()
-			}
-		}()
+	w.awaitingChecks--
+	if w.awaitingChecks > 0 {
+		return
 	}
+
+	w.logger.Warn("watchdog remediation did not recover mount",
+		"mount_path", mountPath,
+		"step", w.state.RemediationStep,
+		"error", checkErr)
+	w.nextRemediationLocked()
 }
 
 // OnMountHealthy is called when a mount transitions to healthy state.
@@ -264,7 +353,7 @@ func (w *Watchdog) OnMountHealthy(mountPath string) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	if w.state.State != WatchdogPendingRestart {
+	if w.state.State != WatchdogPendingRestart && w.state.State != WatchdogRemediating {
 		return
 	}
 
@@ -273,6 +362,11 @@ func (w *Watchdog) OnMountHealthy(mountPath string) {
 		return
 	}
 
+	if w.state.State == WatchdogRemediating {
+		w.endRemediationLocked(mountPath)
+		return
+	}
+
 	w.logger.Info("watchdog restart cancelled",
 		"mount_path", mountPath,
 		"reason", "mount_recovered")
@@ -325,6 +419,7 @@ func (w *Watchdog) triggerRestart(cancelCh <-chan struct{}) {
 	}
 
 	w.state.State = WatchdogTriggered
+	w.state.RestartCount++
 	mountPath := w.state.PendingMount
 	unhealthySince := w.state.UnhealthySince
 	failureCount := w.failureCount
@@ -362,6 +457,7 @@ func (w *Watchdog) triggerRestart(cancelCh <-chan struct{}) {
 		Reason:            fmt.Sprintf("Mount %s unhealthy after %d consecutive failures, triggering pod restart", mountPath, failureCount),
 		FailureCount:      failureCount,
 		UnhealthyDuration: unhealthyDuration,
+		EventReason:       EventReasonRestart,
 	}
 
 	w.logger.Warn("watchdog restart triggered",
@@ -371,16 +467,24 @@ func (w *Watchdog) triggerRestart(cancelCh <-chan struct{}) {
 		"pod", w.podName)
 
 	// Create Kubernetes event (best effort, with short timeout)
-	// Use 5-second timeout since event creation is best-effort and shouldn't delay pod deletion
-	eventCtx, eventCancel := context.WithTimeout(ctx, 5*time.Second)
-	defer eventCancel()
+	w.emitEvent(ctx, event)
+
+	// Attempt pod deletion with retries
+	w.deletePodWithRetry(ctx, event)
+}
+
+// emitEvent creates a Kubernetes event. Event creation is best effort: failures are
+// logged, and a 5-second timeout keeps it from delaying restarts or remediation.
+func (w *Watchdog) emitEvent(ctx context.Context, event *RestartEvent) {
+	if w.k8sClient == nil {
+		return
+	}
+	eventCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
 	if err := w.k8sClient.CreateEvent(eventCtx, event); err != nil {
 		w.logger.Warn("failed to create kubernetes event",
 			"error", err)
 	}
-
-	// Attempt pod deletion with retries
-	w.deletePodWithRetry(ctx, event)
 }
 
 // deletePodWithRetry attempts to delete the pod with exponential backoff.
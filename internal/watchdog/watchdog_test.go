@@ -16,13 +16,13 @@ import (
 
 // Timer Goroutine Pattern (goleak.IgnoreTopFunction)
 //
-// The watchdog's OnMountUnhealthy() spawns a timer goroutine that waits for
-// RestartDelay before triggering a pod restart. This is intentional:
+// The watchdog's OnMountUnhealthy() spawns (via schedulePendingRestartLocked) a timer
+// goroutine that waits for RestartDelay before triggering a pod restart. This is intentional:
 //
 //   - time.AfterFunc() creates a goroutine that calls triggerRestart() after delay
 //   - The goroutine is stored in pendingRestart and can be cancelled via Stop()
 //   - In tests, even after the timer fires, there's a brief window where the
-//     goroutine stack shows as "OnMountUnhealthy.func1"
+//     goroutine stack shows as "schedulePendingRestartLocked.func1"
 //
 // We use goleak.IgnoreTopFunction() for tests involving restart delays because:
 //
@@ -202,7 +202,7 @@ func TestWatchdog_PendingRestartToTriggered(t *testing.T) {
 	defer goleak.VerifyNone(t,
 		// The timer goroutine at OnMountUnhealthy.func1 exits after triggering restart,
 		// but we need to allow time for it to complete
-		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).OnMountUnhealthy.func1"),
+		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).schedulePendingRestartLocked.func1"),
 	)
 	is := is.New(t)
 
@@ -253,7 +253,7 @@ func TestWatchdog_PendingRestartToTriggered(t *testing.T) {
 // TestWatchdog_RestartCancellationOnRecovery tests that recovery cancels pending restart.
 func TestWatchdog_RestartCancellationOnRecovery(t *testing.T) {
 	defer goleak.VerifyNone(t,
-		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).OnMountUnhealthy.func1"),
+		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).schedulePendingRestartLocked.func1"),
 	)
 	is := is.New(t)
 
@@ -306,7 +306,7 @@ func TestWatchdog_RestartCancellationOnRecovery(t *testing.T) {
 // TestWatchdog_DeletePodRetryWithBackoff tests retry logic with exponential backoff.
 func TestWatchdog_DeletePodRetryWithBackoff(t *testing.T) {
 	defer goleak.VerifyNone(t,
-		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).OnMountUnhealthy.func1"),
+		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).schedulePendingRestartLocked.func1"),
 	)
 	is := is.New(t)
 
@@ -584,6 +584,7 @@ func TestWatchdogStatus_String(t *testing.T) {
 		{watchdog.WatchdogArmed, "armed"},
 		{watchdog.WatchdogPendingRestart, "pending_restart"},
 		{watchdog.WatchdogTriggered, "triggered"},
+		{watchdog.WatchdogRemediating, "remediating"},
 		{watchdog.WatchdogStatus(99), "unknown"}, // Invalid value
 	}
 
@@ -625,7 +626,7 @@ func TestWatchdog_ShutdownAbortsRestart(t *testing.T) {
 	defer goleak.VerifyNone(t,
 		// The timer goroutine is still blocked on cancelCh when context is cancelled
 		// This is expected behavior - the goroutine will be cleaned up when the process exits
-		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).OnMountUnhealthy.func1"),
+		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).schedulePendingRestartLocked.func1"),
 	)
 	is := is.New(t)
 
@@ -757,7 +758,7 @@ func TestWatchdog_ConcurrentMountFailures(t *testing.T) {
 func TestWatchdog_RapidStateTransitions(t *testing.T) {
 	defer goleak.VerifyNone(t,
 		// Timer goroutines may still be running cleanup
-		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).OnMountUnhealthy.func1"),
+		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).schedulePendingRestartLocked.func1"),
 	)
 	is := is.New(t)
 
@@ -838,7 +839,7 @@ func TestTransientError(t *testing.T) {
 // simultaneously to verify there are no race conditions.
 func TestWatchdog_ConcurrentMountStateChanges(t *testing.T) {
 	defer goleak.VerifyNone(t,
-		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).OnMountUnhealthy.func1"),
+		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).schedulePendingRestartLocked.func1"),
 	)
 	is := is.New(t)
 
@@ -906,7 +907,7 @@ func TestWatchdog_ConcurrentMountStateChanges(t *testing.T) {
 // TestWatchdog_ImmediateRestart tests restart with zero delay.
 func TestWatchdog_ImmediateRestart(t *testing.T) {
 	defer goleak.VerifyNone(t,
-		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).OnMountUnhealthy.func1"),
+		goleak.IgnoreTopFunction("github.com/cscheib/debrid-mount-monitor/internal/watchdog.(*Watchdog).schedulePendingRestartLocked.func1"),
 	)
 	is := is.New(t)
 